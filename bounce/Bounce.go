@@ -0,0 +1,43 @@
+package bounce
+
+import "github.com/YouDoCom/go-maildsnparsers/rfc3464"
+
+// Bounce is delivery report normalised across all parsers
+type Bounce struct {
+	// Parser is name of parser which found the report, e.g. "rfc3464"
+	Parser string
+
+	// Recipients lists per-recipient results in report order
+	Recipients []Recipient
+
+	// DSN is parsed report if it was found by "rfc3464" parser
+	DSN *rfc3464.DSN
+}
+
+// Recipient is delivery result of single recipient
+type Recipient struct {
+	// Address of recipient
+	Address string
+
+	// Action is canonical action, "failed" for formats without actions.
+	// Unknown vendor actions are kept as is.
+	Action rfc3464.RecipientAction
+
+	// Status is RFC3463 status code in "class.subject.detail" form,
+	// empty if report has none
+	Status string
+
+	// Diagnostic is diagnostic text of remote or reporting MTA
+	Diagnostic string
+}
+
+// Failed returns recipients with "failed" action
+func (b *Bounce) Failed() []Recipient {
+	var failed []Recipient
+	for _, r := range b.Recipients {
+		if r.Action.IsFailed() {
+			failed = append(failed, r)
+		}
+	}
+	return failed
+}
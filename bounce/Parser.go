@@ -0,0 +1,74 @@
+package bounce
+
+import (
+	"net/mail"
+	"sort"
+	"sync"
+)
+
+// Parser is parser of single delivery report format
+type Parser interface {
+	// Name identifies parser, it is stored in Bounce.Parser
+	Name() string
+
+	// Detect checks message header for report of the format.
+	// It must not read message body.
+	Detect(message *mail.Message) bool
+
+	// Parse parses report from message. Error matching ErrorDSNNotFound
+	// means that message has no report of the format, so next parser is tried.
+	Parse(message *mail.Message) (*Bounce, error)
+}
+
+// Priorities of built-in parsers, parsers with lower priority are tried first
+const (
+	PriorityRFC3464           = 100
+	PriorityXMailerDaemon     = 200
+	PriorityXFailedRecipients = 300
+)
+
+type registeredParser struct {
+	parser   Parser
+	priority int
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = []registeredParser{
+		{rfc3464Parser{}, PriorityRFC3464},
+		{xmailerdaemonParser{}, PriorityXMailerDaemon},
+		{xfailedrecipientsParser{}, PriorityXFailedRecipients},
+	}
+)
+
+// Register adds parser to registry. Parsers are tried in ascending priority
+// order, parsers with equal priority in order of registration.
+// Parser with the same name registered earlier is replaced.
+func Register(parser Parser, priority int) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	for i, r := range registry {
+		if r.parser.Name() == parser.Name() {
+			registry = append(registry[:i:i], registry[i+1:]...)
+			break
+		}
+	}
+
+	registry = append(registry, registeredParser{parser: parser, priority: priority})
+	sort.SliceStable(registry, func(i, j int) bool {
+		return registry[i].priority < registry[j].priority
+	})
+}
+
+// Parsers returns registered parsers in order they are tried
+func Parsers() []Parser {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	parsers := make([]Parser, len(registry))
+	for i, r := range registry {
+		parsers[i] = r.parser
+	}
+	return parsers
+}
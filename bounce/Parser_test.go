@@ -0,0 +1,72 @@
+package bounce
+
+import (
+	"net/mail"
+	"strings"
+	"testing"
+
+	"github.com/YouDoCom/go-maildsnparsers/rfc3464"
+	"github.com/stretchr/testify/assert"
+)
+
+type testParser struct {
+	name string
+}
+
+func (p testParser) Name() string { return p.name }
+
+func (p testParser) Detect(message *mail.Message) bool {
+	return message.Header.Get("X-Test-Bounce") != ""
+}
+
+func (p testParser) Parse(message *mail.Message) (*Bounce, error) {
+	if !p.Detect(message) {
+		return nil, ErrorDSNNotFound
+	}
+	return &Bounce{
+		Parser:     p.Name(),
+		Recipients: []Recipient{Recipient{Address: message.Header.Get("X-Test-Bounce"), Action: rfc3464.ActionFailed}},
+	}, nil
+}
+
+func Test_Register(t *testing.T) {
+	defer func() {
+		registryMu.Lock()
+		defer registryMu.Unlock()
+		for i, r := range registry {
+			if r.parser.Name() == "test" {
+				registry = append(registry[:i], registry[i+1:]...)
+				break
+			}
+		}
+	}()
+
+	names := func() []string {
+		var names []string
+		for _, p := range Parsers() {
+			names = append(names, p.Name())
+		}
+		return names
+	}
+
+	assert.Equal(t, []string{"rfc3464", "xmailerdaemon", "xfailedrecipients"}, names())
+
+	Register(testParser{name: "test"}, PriorityXFailedRecipients+1)
+	assert.Equal(t, []string{"rfc3464", "xmailerdaemon", "xfailedrecipients", "test"}, names())
+
+	msg, _ := mail.ReadMessage(strings.NewReader(testXFailedRecipientsMessage))
+	msg.Header["X-Test-Bounce"] = []string{"test@example.com"}
+
+	b, _ := Parse(msg)
+	assert.Equal(t, "xfailedrecipients", b.Parser)
+
+	Register(testParser{name: "test"}, PriorityXMailerDaemon)
+	assert.Equal(t, []string{"rfc3464", "xmailerdaemon", "test", "xfailedrecipients"}, names())
+
+	msg, _ = mail.ReadMessage(strings.NewReader(testXFailedRecipientsMessage))
+	msg.Header["X-Test-Bounce"] = []string{"test@example.com"}
+
+	b, _ = Parse(msg)
+	assert.Equal(t, "test", b.Parser)
+	assert.Equal(t, "test@example.com", b.Recipients[0].Address)
+}
@@ -0,0 +1,19 @@
+/*
+Package bounce detects mail delivery reports of every supported format
+and returns them as single normalised Bounce.
+
+Registered parsers are tried in priority order until one of them
+finds a report:
+
+	b, err := bounce.Parse(msg)
+	if err != nil {
+		return err
+	}
+	for _, r := range b.Recipients {
+		log.Printf("%s: %s %s %s (%s)", r.Address, r.Action, r.Status, r.Diagnostic, b.Parser)
+	}
+
+Built-in parsers are "rfc3464", "xmailerdaemon" and "xfailedrecipients".
+Other formats may be added with Register.
+*/
+package bounce
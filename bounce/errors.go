@@ -0,0 +1,17 @@
+package bounce
+
+import "github.com/YouDoCom/go-maildsnparsers/dsnerrors"
+
+// parserName is used as ParseError.Parser
+const parserName = "bounce"
+
+var (
+	// ErrorNilMessage returned when message is nil
+	ErrorNilMessage = dsnerrors.ErrorNilMessage
+
+	// ErrorDSNNotFound returned when none of registered parsers found report in message
+	ErrorDSNNotFound = dsnerrors.ErrorDSNNotFound
+
+	// ErrorLimitExceeded matches LimitError returned when message body is longer than MaxBytes
+	ErrorLimitExceeded = dsnerrors.ErrorLimitExceeded
+)
@@ -0,0 +1,99 @@
+package bounce
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/mail"
+
+	"github.com/YouDoCom/go-maildsnparsers/dsnerrors"
+	"github.com/YouDoCom/go-maildsnparsers/internal/message"
+)
+
+// DefaultMaxBytes is limit of message body buffered by Parse
+const DefaultMaxBytes = 64 << 20
+
+// IsBounce checks that any of registered parsers detects report in message header
+func IsBounce(message *mail.Message) bool {
+	if message == nil {
+		return false
+	}
+
+	for _, p := range Parsers() {
+		if p.Detect(message) {
+			return true
+		}
+	}
+	return false
+}
+
+// Parse finds delivery report in message trying registered parsers in priority order.
+//
+// Message body is buffered, so every parser reads it from the beginning.
+// Result of the first parser which finds report is returned. When none of them
+// does, the first error not matching ErrorDSNNotFound is returned, or ErrorDSNNotFound
+// if there are no such errors.
+func Parse(message *mail.Message) (*Bounce, error) {
+	if message == nil {
+		return nil, dsnerrors.Wrap(parserName, "", 0, ErrorNilMessage)
+	}
+
+	body, err := readBody(message.Body)
+	if err != nil {
+		return nil, dsnerrors.Wrap(parserName, "", 0, err)
+	}
+
+	var firstErr error
+
+	for _, p := range Parsers() {
+		b, err := p.Parse(&mail.Message{Header: message.Header, Body: bytes.NewReader(body)})
+		if err == nil {
+			return b, nil
+		}
+
+		if firstErr == nil && !errors.Is(err, ErrorDSNNotFound) {
+			firstErr = err
+		}
+	}
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return nil, dsnerrors.Wrap(parserName, "", 0, ErrorDSNNotFound)
+}
+
+// ParseReader finds delivery report in raw message. See Parse.
+// Raw message is read like in rfc3464.ParseReader.
+func ParseReader(ctx context.Context, r io.Reader) (*Bounce, error) {
+	msg, err := message.Read(ctx, r, message.DefaultMaxHeaderBytes)
+	if err != nil {
+		return nil, dsnerrors.Wrap(parserName, "", 0, err)
+	}
+
+	return Parse(msg)
+}
+
+// ParseBytes finds delivery report in raw message. See ParseReader.
+func ParseBytes(ctx context.Context, data []byte) (*Bounce, error) {
+	return ParseReader(ctx, bytes.NewReader(data))
+}
+
+// readBody reads at most DefaultMaxBytes of message body
+func readBody(r io.Reader) ([]byte, error) {
+	if r == nil {
+		return nil, nil
+	}
+
+	data, err := ioutil.ReadAll(io.LimitReader(r, DefaultMaxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) > DefaultMaxBytes {
+		return nil, &dsnerrors.LimitError{Limit: "MaxBytes", Value: DefaultMaxBytes}
+	}
+
+	return data, nil
+}
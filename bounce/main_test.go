@@ -0,0 +1,160 @@
+package bounce
+
+import (
+	"context"
+	"errors"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"github.com/YouDoCom/go-maildsnparsers/rfc3464"
+	"github.com/stretchr/testify/assert"
+)
+
+const testRFC3464Message = `From: MAILER-DAEMON@mx.example.com
+To: sender@example.com
+Subject: Undelivered Mail Returned to Sender
+X-Failed-Recipients: user@example.net
+MIME-Version: 1.0
+Content-Type: multipart/report; report-type=delivery-status; boundary="REPORT"
+
+--REPORT
+Content-Type: text/plain
+
+Delivery failed.
+
+--REPORT
+Content-Type: message/delivery-status
+
+Reporting-MTA: dns; mx.example.com
+
+Final-Recipient: rfc822; User@Example.NET
+Action: Failed (permanent error)
+Status: 5.1.1
+Diagnostic-Code: smtp; 550 5.1.1 User unknown
+
+Final-Recipient: rfc822; other@example.net
+Action: delayed
+Status: 4.0.0
+Diagnostic-Code: smtp; 451 4.2.0 Try again later
+
+--REPORT--
+`
+
+const testXMailerDaemonMessage = `From: Mail Delivery System <Mailer-Daemon@mail01>
+To: sender@example.com
+X-Mailer-Daemon-Recipients: one@example.com, two@example.com
+X-Mailer-Daemon-Error: 550 5.1.1 user_not_found
+
+A message that you sent could not be delivered.
+`
+
+const testXFailedRecipientsMessage = `From: Mail Delivery System <Mailer-Daemon@mail01>
+To: sender@example.com
+X-Failed-Recipients: one@example.com; two@example.com
+
+A message that you sent could not be delivered.
+`
+
+func Test_Parse(t *testing.T) {
+	type fixture struct {
+		name     string
+		value    string
+		expected *Bounce
+	}
+
+	fixtures := []fixture{
+		fixture{
+			name:  "rfc3464",
+			value: testRFC3464Message,
+			expected: &Bounce{
+				Parser: "rfc3464",
+				Recipients: []Recipient{
+					Recipient{Address: "User@example.net", Action: rfc3464.ActionFailed, Status: "5.1.1", Diagnostic: "550 5.1.1 User unknown"},
+					Recipient{Address: "other@example.net", Action: rfc3464.ActionDelayed, Status: "4.0.0", Diagnostic: "451 4.2.0 Try again later"},
+				},
+			},
+		},
+		fixture{
+			name:  "xmailerdaemon",
+			value: testXMailerDaemonMessage,
+			expected: &Bounce{
+				Parser: "xmailerdaemon",
+				Recipients: []Recipient{
+					Recipient{Address: "one@example.com", Action: rfc3464.ActionFailed, Status: "5.1.1", Diagnostic: "550 5.1.1 user_not_found"},
+					Recipient{Address: "two@example.com", Action: rfc3464.ActionFailed, Status: "5.1.1", Diagnostic: "550 5.1.1 user_not_found"},
+				},
+			},
+		},
+		fixture{
+			name:  "xfailedrecipients",
+			value: testXFailedRecipientsMessage,
+			expected: &Bounce{
+				Parser: "xfailedrecipients",
+				Recipients: []Recipient{
+					Recipient{Address: "one@example.com", Action: rfc3464.ActionFailed},
+					Recipient{Address: "two@example.com", Action: rfc3464.ActionFailed},
+				},
+			},
+		},
+	}
+
+	for _, f := range fixtures {
+		msg, _ := mail.ReadMessage(strings.NewReader(f.value))
+
+		assert.True(t, IsBounce(msg), "Fixture: %s", f.name)
+
+		b, err := Parse(msg)
+		if !assert.NoError(t, err, "Fixture: %s", f.name) {
+			continue
+		}
+
+		if b.DSN != nil {
+			assert.Equal(t, "mx.example.com", b.DSN.ReportingMTA.Value, "Fixture: %s", f.name)
+			b.DSN = nil
+		}
+
+		assert.Equal(t, f.expected, b, "Fixture: %s", f.name)
+	}
+}
+
+func Test_Parse_NotFound(t *testing.T) {
+	msg, _ := mail.ReadMessage(strings.NewReader("From: user@example.com\nSubject: Hello\n\nHi!\n"))
+
+	assert.False(t, IsBounce(msg))
+
+	_, err := Parse(msg)
+	assert.True(t, errors.Is(err, ErrorDSNNotFound))
+	assert.EqualError(t, err, "bounce: DSN not found in message")
+
+	_, err = Parse(nil)
+	assert.True(t, errors.Is(err, ErrorNilMessage))
+
+	assert.False(t, IsBounce(nil))
+}
+
+func Test_Parse_Error(t *testing.T) {
+	value := strings.Replace(testRFC3464Message, "Action: delayed", "Action delayed", 1)
+
+	// malformed record is skipped and recorded in DSN
+	msg, _ := mail.ReadMessage(strings.NewReader(value))
+	b, err := Parse(msg)
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, "rfc3464", b.Parser)
+		assert.Len(t, b.Recipients, 1)
+		if assert.Len(t, b.DSN.Errors, 1) {
+			assert.True(t, errors.Is(b.DSN.Errors[0].Err, rfc3464.ErrorMalformedField))
+			assert.Equal(t, "Action delayed", b.DSN.Errors[0].Raw)
+		}
+	}
+}
+
+func Test_ParseBytes(t *testing.T) {
+	b, err := ParseBytes(context.Background(), []byte(strings.Replace(testXFailedRecipientsMessage, "\n", "\r\n", -1)))
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, "xfailedrecipients", b.Parser)
+		assert.Len(t, b.Failed(), 2)
+	}
+}
@@ -0,0 +1,116 @@
+package bounce
+
+import (
+	"net/mail"
+	"strings"
+
+	"github.com/YouDoCom/go-maildsnparsers/rfc3464"
+	"github.com/YouDoCom/go-maildsnparsers/xfailedrecipients"
+	"github.com/YouDoCom/go-maildsnparsers/xmailerdaemon"
+)
+
+type rfc3464Parser struct{}
+
+func (rfc3464Parser) Name() string { return "rfc3464" }
+
+func (rfc3464Parser) Detect(message *mail.Message) bool { return rfc3464.IsDSN(message) }
+
+func (p rfc3464Parser) Parse(message *mail.Message) (*Bounce, error) {
+	// malformed records are skipped, so well-formed ones are not lost
+	dsn, err := rfc3464.ParseWithOptions(message, rfc3464.ParseOptions{ContinueOnError: true})
+	if err != nil {
+		return nil, err
+	}
+
+	b := Bounce{Parser: p.Name(), DSN: dsn}
+
+	for i := range dsn.Recipients {
+		record := &dsn.Recipients[i]
+
+		r := Recipient{
+			Address:    record.FinalRecipient.Value,
+			Diagnostic: record.DiagnosticCode.Value,
+		}
+
+		if addr, err := record.Recipient(); err == nil {
+			r.Address = addr.Address
+		}
+
+		r.Action, _ = rfc3464.ParseRecipientAction(string(record.Action))
+
+		if status, err := record.EnhancedStatus(); err == nil {
+			r.Status = status.String()
+		} else {
+			r.Status = diagnosticStatus(r.Diagnostic)
+		}
+
+		b.Recipients = append(b.Recipients, r)
+	}
+
+	return &b, nil
+}
+
+type xmailerdaemonParser struct{}
+
+func (xmailerdaemonParser) Name() string { return "xmailerdaemon" }
+
+func (xmailerdaemonParser) Detect(message *mail.Message) bool { return xmailerdaemon.IsDSN(message) }
+
+func (p xmailerdaemonParser) Parse(message *mail.Message) (*Bounce, error) {
+	results, err := xmailerdaemon.Parse(message)
+	if err != nil {
+		return nil, err
+	}
+
+	b := Bounce{Parser: p.Name()}
+
+	for _, result := range results {
+		b.Recipients = append(b.Recipients, Recipient{
+			Address:    result.Address,
+			Action:     rfc3464.ActionFailed,
+			Status:     diagnosticStatus(result.Reason),
+			Diagnostic: result.Reason,
+		})
+	}
+
+	return &b, nil
+}
+
+type xfailedrecipientsParser struct{}
+
+func (xfailedrecipientsParser) Name() string { return "xfailedrecipients" }
+
+func (xfailedrecipientsParser) Detect(message *mail.Message) bool {
+	return xfailedrecipients.IsDSN(message)
+}
+
+func (p xfailedrecipientsParser) Parse(message *mail.Message) (*Bounce, error) {
+	recipients, err := xfailedrecipients.Parse(message)
+	if err != nil {
+		return nil, err
+	}
+
+	b := Bounce{Parser: p.Name()}
+
+	for _, address := range recipients {
+		if address = strings.TrimSpace(address); address == "" {
+			continue
+		}
+
+		b.Recipients = append(b.Recipients, Recipient{
+			Address: address,
+			Action:  rfc3464.ActionFailed,
+		})
+	}
+
+	return &b, nil
+}
+
+// diagnosticStatus returns enhanced status code of SMTP reply in diagnostic text
+func diagnosticStatus(diagnostic string) string {
+	reply, err := rfc3464.ParseSMTPDiagnostic(diagnostic)
+	if err != nil || !reply.HasEnhancedStatus() {
+		return ""
+	}
+	return reply.EnhancedStatus.String()
+}
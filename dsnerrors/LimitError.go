@@ -0,0 +1,22 @@
+package dsnerrors
+
+import "fmt"
+
+// LimitError returned when message exceeds one of parse limits.
+// It matches ErrorLimitExceeded with errors.Is.
+type LimitError struct {
+	// Limit is name of exceeded limit, e.g. "MaxRecipients"
+	Limit string
+	// Value of the limit
+	Value int64
+}
+
+// Error returns description of exceeded limit
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("%s: %s %d", ErrorLimitExceeded, e.Limit, e.Value)
+}
+
+// Is reports whether target is ErrorLimitExceeded
+func (e *LimitError) Is(target error) bool {
+	return target == ErrorLimitExceeded
+}
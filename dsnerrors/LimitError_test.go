@@ -0,0 +1,21 @@
+package dsnerrors
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_LimitError(t *testing.T) {
+	err := Wrap("rfc3464", "2", 0, &LimitError{Limit: "MaxRecipients", Value: 1000})
+
+	assert.EqualError(t, err, "rfc3464: part 2: Limit exceeded: MaxRecipients 1000")
+	assert.True(t, errors.Is(err, ErrorLimitExceeded))
+	assert.False(t, errors.Is(err, ErrorDSNNotFound))
+
+	var limitErr *LimitError
+	if assert.True(t, errors.As(err, &limitErr)) {
+		assert.Equal(t, "MaxRecipients", limitErr.Limit)
+	}
+}
@@ -0,0 +1,20 @@
+package dsnerrors
+
+import "fmt"
+
+// MissingFieldError returned when required field of report is absent.
+// It matches ErrorRequiredFieldMissing with errors.Is.
+type MissingFieldError struct {
+	// Field is name of missing field, e.g. "Disposition"
+	Field string
+}
+
+// Error returns description of missing field
+func (e *MissingFieldError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrorRequiredFieldMissing, e.Field)
+}
+
+// Is reports whether target is ErrorRequiredFieldMissing
+func (e *MissingFieldError) Is(target error) bool {
+	return target == ErrorRequiredFieldMissing
+}
@@ -0,0 +1,21 @@
+package dsnerrors
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MissingFieldError(t *testing.T) {
+	err := Wrap("rfc8098", "2", 0, &MissingFieldError{Field: "Disposition"})
+
+	assert.EqualError(t, err, "rfc8098: part 2: Required field missing: Disposition")
+	assert.True(t, errors.Is(err, ErrorRequiredFieldMissing))
+	assert.False(t, errors.Is(err, ErrorMalformedField))
+
+	var missingErr *MissingFieldError
+	if assert.True(t, errors.As(err, &missingErr)) {
+		assert.Equal(t, "Disposition", missingErr.Field)
+	}
+}
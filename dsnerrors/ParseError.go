@@ -0,0 +1,69 @@
+package dsnerrors
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseError is error returned by parsers with location where it occurred
+type ParseError struct {
+	// Parser is name of parser package, e.g. "rfc3464"
+	Parser string
+	// Part is path of MIME part, e.g. "2" or "1.3".
+	// Empty path means message itself.
+	Part string
+	// Line is 1-based line number within part, zero if unknown
+	Line int
+	// Err is underlying error
+	Err error
+}
+
+// Error returns description of error prefixed with its location
+func (e *ParseError) Error() string {
+	parts := []string{e.Parser}
+	if e.Part != "" {
+		parts = append(parts, "part "+e.Part)
+	}
+	if e.Line > 0 {
+		parts = append(parts, fmt.Sprintf("line %d", e.Line))
+	}
+	parts = append(parts, fmt.Sprint(e.Err))
+
+	return strings.Join(parts, ": ")
+}
+
+// Unwrap returns underlying error
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Wrap returns ParseError for err. Nil is returned for nil err.
+// If err is ParseError already, it is returned as is.
+func Wrap(parser, part string, line int, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if _, ok := err.(*ParseError); ok {
+		return err
+	}
+
+	return &ParseError{Parser: parser, Part: part, Line: line, Err: err}
+}
+
+// WithParser returns err located at the same place as reported by parser.
+// It is used by parsers built on top of other parsers. Errors which are
+// not ParseError are wrapped.
+func WithParser(parser string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if e, ok := err.(*ParseError); ok {
+		located := *e
+		located.Parser = parser
+		return &located
+	}
+
+	return &ParseError{Parser: parser, Err: err}
+}
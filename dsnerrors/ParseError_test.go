@@ -0,0 +1,73 @@
+package dsnerrors
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseError(t *testing.T) {
+	type fixture struct {
+		err      *ParseError
+		expected string
+	}
+
+	fixtures := []fixture{
+		fixture{
+			err:      &ParseError{Parser: "rfc3464", Err: ErrorNilMessage},
+			expected: "rfc3464: Message is nil",
+		},
+		fixture{
+			err:      &ParseError{Parser: "rfc3464", Part: "1.2", Line: 8, Err: ErrorMalformedField},
+			expected: "rfc3464: part 1.2: line 8: Malformed field",
+		},
+		fixture{
+			err:      &ParseError{Parser: "rfc3464", Part: "2", Err: io.ErrUnexpectedEOF},
+			expected: "rfc3464: part 2: unexpected EOF",
+		},
+	}
+
+	for _, f := range fixtures {
+		assert.Equal(t, f.expected, f.err.Error())
+		assert.True(t, errors.Is(f.err, f.err.Err))
+	}
+}
+
+func Test_Wrap(t *testing.T) {
+	assert.Nil(t, Wrap("rfc3464", "", 0, nil))
+
+	err := Wrap("rfc3464", "2", 0, ErrorDSNNotFound)
+
+	var parseErr *ParseError
+	if assert.True(t, errors.As(err, &parseErr)) {
+		assert.Equal(t, "rfc3464", parseErr.Parser)
+		assert.Equal(t, "2", parseErr.Part)
+	}
+
+	assert.True(t, err == Wrap("xfailedrecipients", "", 0, err), "ParseError is not wrapped twice")
+}
+
+func Test_WithParser(t *testing.T) {
+	assert.Nil(t, WithParser("rfc8098", nil))
+
+	located := Wrap("rfc3464", "2", 5, ErrorMalformedField)
+	err := WithParser("rfc8098", located)
+
+	assert.EqualError(t, err, "rfc8098: part 2: line 5: Malformed field")
+	assert.EqualError(t, located, "rfc3464: part 2: line 5: Malformed field")
+	assert.True(t, errors.Is(err, ErrorMalformedField))
+
+	assert.EqualError(t, WithParser("rfc8098", io.ErrUnexpectedEOF), "rfc8098: unexpected EOF")
+}
+
+func Test_Derive(t *testing.T) {
+	err := Derive(ErrorDSNNotFound, "DSN part not found in message body")
+
+	assert.Equal(t, "DSN part not found in message body", err.Error())
+	assert.True(t, errors.Is(err, ErrorDSNNotFound))
+	assert.True(t, errors.Is(Wrap("rfc3464", "", 0, err), ErrorDSNNotFound))
+	assert.True(t, errors.Is(Wrap("rfc3464", "", 0, err), err))
+	assert.False(t, errors.Is(err, ErrorNilMessage))
+}
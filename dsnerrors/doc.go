@@ -0,0 +1,14 @@
+/*
+Package dsnerrors contains error model shared by mail delivery reports parsers.
+
+Every parser wraps its errors into ParseError, which carries parser name
+and location of the error. Sentinel errors of parsers match shared
+sentinels of this package with errors.Is, so the same error-handling path
+works for every parser:
+
+	dsn, err := rfc3464.Parse(msg)
+	if errors.Is(err, dsnerrors.ErrorDSNNotFound) {
+		recipients, err = xfailedrecipients.Parse(msg)
+	}
+*/
+package dsnerrors
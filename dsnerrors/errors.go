@@ -0,0 +1,46 @@
+package dsnerrors
+
+import "errors"
+
+var (
+	// ErrorNilMessage returned when message is nil
+	ErrorNilMessage = errors.New("Message is nil")
+
+	// ErrorDSNNotFound retured when DSN cannot be found in message
+	ErrorDSNNotFound = errors.New("DSN not found in message")
+
+	// ErrorMalformedField returned when line of report is not valid field
+	ErrorMalformedField = errors.New("Malformed field")
+
+	// ErrorUnknownTransferEncoding returned when part has unsupported Content-Transfer-Encoding
+	ErrorUnknownTransferEncoding = errors.New("Unknown Content-Transfer-Encoding")
+
+	// ErrorUnknownCharset returned when text is in charset which cannot be decoded
+	ErrorUnknownCharset = errors.New("Unknown charset")
+
+	// ErrorLimitExceeded matches LimitError returned when message exceeds parse limits
+	ErrorLimitExceeded = errors.New("Limit exceeded")
+
+	// ErrorRequiredFieldMissing matches MissingFieldError returned when required field is absent
+	ErrorRequiredFieldMissing = errors.New("Required field missing")
+)
+
+// sentinel is parser specific error which matches shared sentinel
+type sentinel struct {
+	text   string
+	parent error
+}
+
+func (e *sentinel) Error() string {
+	return e.text
+}
+
+func (e *sentinel) Is(target error) bool {
+	return target == e.parent
+}
+
+// Derive returns new sentinel error with given text
+// which matches parent with errors.Is
+func Derive(parent error, text string) error {
+	return &sentinel{text: text, parent: parent}
+}
@@ -0,0 +1,49 @@
+package fieldblock
+
+import (
+	"net/textproto"
+	"strings"
+)
+
+// Field is field as it is written in machine-readable part of report
+type Field struct {
+	// Name of the field as written, e.g. "Final-recipient"
+	Name string
+	// Value is unfolded value with leading and trailing white space removed
+	Value string
+	// Raw is value as written after colon. Continuation lines are kept
+	// and joined with CRLF.
+	Raw string
+	// Line is 1-based number of the first line of the field in the part
+	Line int
+}
+
+// Fields is list of fields in original order.
+// Repeated fields are kept as separate entries.
+type Fields []Field
+
+// Lookup returns all fields with given name, name is case-insensitive
+func (f Fields) Lookup(name string) []Field {
+	var found []Field
+	for _, field := range f {
+		if strings.EqualFold(field.Name, name) {
+			found = append(found, field)
+		}
+	}
+	return found
+}
+
+// Count returns number of fields with given name, name is case-insensitive
+func (f Fields) Count(name string) int {
+	return len(f.Lookup(name))
+}
+
+// Header converts fields to MIME header with canonical keys.
+// Values of repeated fields are kept in order.
+func (f Fields) Header() textproto.MIMEHeader {
+	hdr := make(textproto.MIMEHeader, len(f))
+	for _, field := range f {
+		hdr.Add(field.Name, field.Value)
+	}
+	return hdr
+}
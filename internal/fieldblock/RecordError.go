@@ -0,0 +1,28 @@
+package fieldblock
+
+import (
+	"fmt"
+)
+
+// RecordError describes malformed line of block of fields
+type RecordError struct {
+	// Block is index of blank line separated block in the part.
+	// Block 0 contains per-message fields, following blocks are per-recipient.
+	Block int
+	// Line is 1-based number of malformed line in the part
+	Line int
+	// Raw is text of malformed line
+	Raw string
+	// Err is cause of error
+	Err error
+}
+
+// Error returns description of error with its location
+func (e *RecordError) Error() string {
+	return fmt.Sprintf("block %d, line %d: %s: %q", e.Block, e.Line, e.Err, e.Raw)
+}
+
+// Unwrap returns cause of error
+func (e *RecordError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,145 @@
+// Package fieldblock reads machine-readable parts of reports which consist
+// of blank line separated blocks of fields, e.g. message/delivery-status,
+// message/disposition-notification or message/feedback-report.
+// It is shared by parsers of all report types.
+package fieldblock
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	"github.com/YouDoCom/go-maildsnparsers/dsnerrors"
+	"github.com/YouDoCom/go-maildsnparsers/internal/message"
+)
+
+// Line is line of the part with its 1-based number
+type Line struct {
+	N    int
+	Text string
+}
+
+// Read splits part into blocks separated by blank lines.
+// Empty blocks produced by repeated blank lines are dropped.
+// LimitError is returned when block is longer than maxBlockBytes
+// or there are more than maxRecipients blocks following the first one.
+// Zero limit means no limit.
+func Read(reader io.Reader, maxBlockBytes, maxRecipients int) ([][]Line, error) {
+	r := bufio.NewReader(reader)
+
+	var (
+		blocks     [][]Line
+		block      []Line
+		blockBytes int
+	)
+
+	for n := 1; ; n++ {
+		max := -1
+		if maxBlockBytes > 0 {
+			max = maxBlockBytes - blockBytes
+		}
+
+		line, err := message.ReadLine(r, max)
+		if err != nil && err != io.EOF {
+			if err == message.ErrLineTooLong {
+				err = &dsnerrors.LimitError{Limit: "MaxHeaderBytes", Value: int64(maxBlockBytes)}
+			}
+			return nil, err
+		}
+
+		if line == "" && err == io.EOF {
+			break
+		}
+
+		blockBytes += len(line)
+		line = strings.TrimRight(line, "\r\n")
+
+		if strings.TrimSpace(line) == "" {
+			if block != nil {
+				blocks = append(blocks, block)
+				block = nil
+
+				if maxRecipients > 0 && len(blocks) > maxRecipients+1 {
+					return nil, &dsnerrors.LimitError{Limit: "MaxRecipients", Value: int64(maxRecipients)}
+				}
+			}
+			blockBytes = 0
+		} else {
+			block = append(block, Line{N: n, Text: line})
+		}
+
+		if err == io.EOF {
+			break
+		}
+	}
+
+	if block != nil {
+		blocks = append(blocks, block)
+
+		if maxRecipients > 0 && len(blocks) > maxRecipients+1 {
+			return nil, &dsnerrors.LimitError{Limit: "MaxRecipients", Value: int64(maxRecipients)}
+		}
+	}
+
+	return blocks, nil
+}
+
+// Parse parses block of fields in the same way as textproto.Reader.ReadMIMEHeader
+// does, but keeps fields as written and reports location of malformed line.
+// Fields preceding malformed line are returned along with the error.
+func Parse(index int, lines []Line) (Fields, *RecordError) {
+	var fields Fields
+
+	for _, line := range lines {
+		if line.Text[0] == ' ' || line.Text[0] == '\t' {
+			if len(fields) == 0 {
+				return fields, &RecordError{Block: index, Line: line.N, Raw: line.Text, Err: dsnerrors.ErrorMalformedField}
+			}
+
+			field := &fields[len(fields)-1]
+			field.Value = strings.TrimSpace(field.Value + " " + strings.TrimSpace(line.Text))
+			field.Raw += "\r\n" + line.Text
+			continue
+		}
+
+		i := strings.IndexByte(line.Text, ':')
+		if i <= 0 || !message.IsFieldName(line.Text[:i]) {
+			return fields, &RecordError{Block: index, Line: line.N, Raw: line.Text, Err: dsnerrors.ErrorMalformedField}
+		}
+
+		fields = append(fields, Field{
+			Name:  line.Text[:i],
+			Value: strings.TrimSpace(line.Text[i+1:]),
+			Raw:   line.Text[i+1:],
+			Line:  line.N,
+		})
+	}
+
+	return fields, nil
+}
+
+// ReadFields reads fields of part which consists of a single block of
+// fields, e.g. message/feedback-report. Blank lines are tolerated and
+// fields of all blocks are returned in order.
+//
+// Size of every block is limited by maxBlockBytes. *RecordError with
+// location of malformed line is returned along with fields read so far.
+func ReadFields(reader io.Reader, maxBlockBytes int) (Fields, error) {
+	blocks, err := Read(reader, maxBlockBytes, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields Fields
+
+	for i, block := range blocks {
+		blockFields, recordErr := Parse(i, block)
+		fields = append(fields, blockFields...)
+
+		if recordErr != nil {
+			return fields, recordErr
+		}
+	}
+
+	return fields, nil
+}
@@ -0,0 +1,59 @@
+package fieldblock
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/YouDoCom/go-maildsnparsers/dsnerrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Parse(t *testing.T) {
+	lines := []Line{
+		Line{N: 3, Text: "final-recipient: rfc822;user@example.com"},
+		Line{N: 4, Text: "Diagnostic-Code: smtp; 550 mailbox"},
+		Line{N: 5, Text: "    unavailable "},
+		Line{N: 6, Text: "X-Note:"},
+		Line{N: 7, Text: "STATUS: 5.1.1"},
+		Line{N: 8, Text: "Status:5.1.2"},
+	}
+
+	fields, err := Parse(1, lines)
+
+	assert.Nil(t, err)
+	assert.Equal(t, Fields{
+		Field{Name: "final-recipient", Value: "rfc822;user@example.com", Raw: " rfc822;user@example.com", Line: 3},
+		Field{Name: "Diagnostic-Code", Value: "smtp; 550 mailbox unavailable", Raw: " smtp; 550 mailbox\r\n    unavailable ", Line: 4},
+		Field{Name: "X-Note", Value: "", Raw: "", Line: 6},
+		Field{Name: "STATUS", Value: "5.1.1", Raw: " 5.1.1", Line: 7},
+		Field{Name: "Status", Value: "5.1.2", Raw: "5.1.2", Line: 8},
+	}, fields)
+
+	assert.Equal(t, 2, fields.Count("status"))
+	assert.Equal(t, []Field{fields[0]}, fields.Lookup("Final-Recipient"))
+}
+
+func Test_ReadFields(t *testing.T) {
+	value := "Feedback-Type: abuse\r\nUser-Agent: SomeGenerator/1.0\r\n\r\nOriginal-Rcpt-To: <user@example.com>\r\nOriginal-Rcpt-To:\r\n  <other@example.com>\r\n"
+
+	fields, err := ReadFields(strings.NewReader(value), 0)
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, 4, len(fields))
+		assert.Equal(t, "User-Agent", fields[1].Name)
+		assert.Equal(t, []string{"<user@example.com>", "<other@example.com>"}, fields.Header()["Original-Rcpt-To"])
+	}
+
+	fields, err = ReadFields(strings.NewReader("Feedback-Type: abuse\nmalformed line\n"), 0)
+
+	var recordErr *RecordError
+	if assert.True(t, errors.As(err, &recordErr)) {
+		assert.Equal(t, 2, recordErr.Line)
+		assert.Equal(t, dsnerrors.ErrorMalformedField, recordErr.Err)
+	}
+	assert.Equal(t, Fields{Field{Name: "Feedback-Type", Value: "abuse", Raw: " abuse", Line: 1}}, fields)
+
+	_, err = ReadFields(strings.NewReader(value), 16)
+	assert.True(t, errors.Is(err, dsnerrors.ErrorLimitExceeded))
+}
@@ -0,0 +1,163 @@
+// Package message reads mail messages tolerating common deviations
+// of raw input from RFC5322: bare LF line endings, leading mbox "From "
+// lines, UTF-8 byte order mark and malformed header lines.
+package message
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/mail"
+	"net/textproto"
+	"strings"
+
+	"github.com/YouDoCom/go-maildsnparsers/dsnerrors"
+)
+
+// DefaultMaxHeaderBytes is default limit of top-level header size
+const DefaultMaxHeaderBytes = 1 << 20
+
+// HeaderLimit returns header size limit for MaxHeaderBytes option:
+// DefaultMaxHeaderBytes for zero value and zero, i.e. no limit, for negative.
+func HeaderLimit(maxHeaderBytes int) int {
+	switch {
+	case maxHeaderBytes == 0:
+		return DefaultMaxHeaderBytes
+	case maxHeaderBytes < 0:
+		return 0
+	}
+	return maxHeaderBytes
+}
+
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// ErrLineTooLong returned by ReadLine when line is longer than limit
+var ErrLineTooLong = errors.New("Line too long")
+
+// Read reads message from r. Unlike mail.ReadMessage it skips header
+// lines which are not valid fields instead of failing.
+//
+// Reading is stopped with ctx.Err() when ctx is done, including reads
+// of message body. LimitError is returned when header is longer than
+// maxHeaderBytes, zero maxHeaderBytes disables the limit.
+func Read(ctx context.Context, r io.Reader, maxHeaderBytes int) (*mail.Message, error) {
+	br := bufio.NewReader(&contextReader{ctx: ctx, r: r})
+
+	if prefix, _ := br.Peek(len(utf8BOM)); bytes.Equal(prefix, utf8BOM) {
+		br.Discard(len(utf8BOM))
+	}
+
+	hdr := make(mail.Header)
+
+	var (
+		key, value string
+		size       int
+		first      = true
+	)
+
+	for {
+		max := -1
+		if maxHeaderBytes > 0 {
+			max = maxHeaderBytes - size
+		}
+
+		line, err := ReadLine(br, max)
+		if err == ErrLineTooLong {
+			return nil, &dsnerrors.LimitError{Limit: "MaxHeaderBytes", Value: int64(maxHeaderBytes)}
+		}
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		if line == "" && err == io.EOF {
+			if first {
+				return nil, io.EOF
+			}
+			break
+		}
+
+		size += len(line)
+		line = strings.TrimRight(line, "\r\n")
+
+		// mbox separator line preceding the message
+		if first && strings.HasPrefix(line, "From ") {
+			continue
+		}
+		first = false
+
+		if line == "" {
+			break
+		}
+
+		if line[0] == ' ' || line[0] == '\t' {
+			if key != "" {
+				value += " " + strings.TrimSpace(line)
+			}
+		} else {
+			if key != "" {
+				hdr[key] = append(hdr[key], value)
+			}
+
+			key, value = "", ""
+			if i := strings.IndexByte(line, ':'); i > 0 && IsFieldName(line[:i]) {
+				key = textproto.CanonicalMIMEHeaderKey(line[:i])
+				value = strings.TrimSpace(line[i+1:])
+			}
+		}
+
+		if err == io.EOF {
+			break
+		}
+	}
+
+	if key != "" {
+		hdr[key] = append(hdr[key], value)
+	}
+
+	return &mail.Message{Header: hdr, Body: br}, nil
+}
+
+// ReadLine reads line including line break. Unlike bufio.Reader.ReadString
+// it stops with ErrLineTooLong as soon as line gets longer than max bytes,
+// so huge lines are never buffered. Negative max means no limit.
+func ReadLine(r *bufio.Reader, max int) (string, error) {
+	var line []byte
+
+	for {
+		chunk, err := r.ReadSlice('\n')
+		line = append(line, chunk...)
+
+		if max >= 0 && len(line) > max {
+			return "", ErrLineTooLong
+		}
+
+		if err != bufio.ErrBufferFull {
+			return string(line), err
+		}
+	}
+}
+
+// IsFieldName checks that name consists of printable US-ASCII characters
+// except colon, as required by RFC5322 field-name
+func IsFieldName(name string) bool {
+	for i := 0; i < len(name); i++ {
+		if name[i] < 33 || name[i] > 126 {
+			return false
+		}
+	}
+	return true
+}
+
+// contextReader stops reading when context is done
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (c *contextReader) Read(p []byte) (int, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return c.r.Read(p)
+}
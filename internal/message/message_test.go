@@ -0,0 +1,89 @@
+package message
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"github.com/YouDoCom/go-maildsnparsers/dsnerrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Read(t *testing.T) {
+	type fixture struct {
+		value          string
+		expectedHeader mail.Header
+		expectedBody   string
+	}
+
+	fixtures := []fixture{
+		fixture{
+			value:          "Subject: test\r\nX-Folded: a\r\n  b\r\n\r\nbody\r\n",
+			expectedHeader: mail.Header{"Subject": {"test"}, "X-Folded": {"a b"}},
+			expectedBody:   "body\r\n",
+		},
+		fixture{
+			value:          "From MAILER-DAEMON Thu Jul  7 17:16:05 1994\nsubject: test\nsubject: again\n\nbody\n",
+			expectedHeader: mail.Header{"Subject": {"test", "again"}},
+			expectedBody:   "body\n",
+		},
+		fixture{
+			value:          "\xEF\xBB\xBFSubject: bom\n\nbody",
+			expectedHeader: mail.Header{"Subject": {"bom"}},
+			expectedBody:   "body",
+		},
+		fixture{
+			value:          " leading continuation\nnot a field\nBad Name: x\nSubject: kept\n\tfolded\n\n",
+			expectedHeader: mail.Header{"Subject": {"kept folded"}},
+		},
+		fixture{
+			value:          "Subject: no body",
+			expectedHeader: mail.Header{"Subject": {"no body"}},
+		},
+	}
+
+	for _, f := range fixtures {
+		msg, err := Read(context.Background(), strings.NewReader(f.value), 0)
+		if !assert.NoError(t, err, "Fixture: %q", f.value) {
+			continue
+		}
+
+		body, _ := ioutil.ReadAll(msg.Body)
+
+		assert.Equal(t, f.expectedHeader, msg.Header, "Fixture: %q", f.value)
+		assert.Equal(t, f.expectedBody, string(body), "Fixture: %q", f.value)
+	}
+}
+
+func Test_Read_Errors(t *testing.T) {
+	_, err := Read(context.Background(), strings.NewReader(""), 0)
+	assert.Equal(t, io.EOF, err)
+
+	_, err = Read(context.Background(), strings.NewReader("Subject: "+strings.Repeat("x", 100)+"\n\n"), 64)
+	assert.True(t, errors.Is(err, dsnerrors.ErrorLimitExceeded))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = Read(ctx, strings.NewReader("Subject: test\n\n"), 0)
+	assert.Equal(t, context.Canceled, err)
+}
+
+func Test_Read_CancelBody(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	msg, err := Read(ctx, strings.NewReader("Subject: test\n\n"+strings.Repeat("x", 1<<16)), 0)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	cancel()
+
+	_, err = ioutil.ReadAll(msg.Body)
+	assert.Equal(t, context.Canceled, err)
+}
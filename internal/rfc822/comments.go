@@ -0,0 +1,97 @@
+// Package rfc822 handles RFC822 comments in structured fields of reports.
+// It is shared by parsers of all report types.
+package rfc822
+
+import "strings"
+
+// SplitComments removes RFC822 comments from structured field value.
+// Value is returned with runs of white space replaced by single space
+// and comments without enclosing parentheses. Nested comments are kept
+// inside enclosing comment, parentheses in quoted strings are not comments.
+// Line breaks separating values of repeated fields are kept.
+func SplitComments(value string) (string, []string) {
+	var (
+		b, comment      strings.Builder
+		comments        []string
+		depth           int
+		escaped, quoted bool
+	)
+
+	for _, r := range value {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case depth == 0 && r == '"':
+			quoted = !quoted
+		case quoted:
+		case r == '(':
+			depth++
+			if depth == 1 {
+				b.WriteByte(' ')
+				continue
+			}
+		case r == ')' && depth > 0:
+			depth--
+			if depth == 0 {
+				comments = appendComment(comments, comment.String())
+				comment.Reset()
+				b.WriteByte(' ')
+				continue
+			}
+		}
+
+		if depth > 0 {
+			comment.WriteRune(r)
+		} else {
+			b.WriteRune(r)
+		}
+	}
+
+	// unterminated comment lasts till the end of value
+	if depth > 0 {
+		comments = appendComment(comments, comment.String())
+	}
+
+	return normalizeSpace(b.String()), comments
+}
+
+// StripComments removes RFC822 comments from structured field value.
+// See SplitComments.
+func StripComments(value string) string {
+	value, _ = SplitComments(value)
+	return value
+}
+
+// SplitAddressField splits "address-type; generic-address" value of
+// Original-Recipient or Final-Recipient field. RFC822 comments are removed
+// from address-type and returned. Generic-address is *text, which may
+// contain parentheses, e.g. "/S=Doe(Sales)/" of x400 address, so it is
+// kept verbatim.
+func SplitAddressField(value string) (addressType, address string, comments []string) {
+	i := strings.IndexByte(value, ';')
+	if i < 0 {
+		return "", strings.TrimSpace(value), nil
+	}
+
+	addressType, comments = SplitComments(value[:i])
+	return addressType, strings.TrimSpace(value[i+1:]), comments
+}
+
+func appendComment(comments []string, comment string) []string {
+	if comment = normalizeSpace(comment); comment != "" {
+		comments = append(comments, comment)
+	}
+	return comments
+}
+
+// normalizeSpace replaces runs of white space in every line with single space
+// and trims lines
+func normalizeSpace(value string) string {
+	lines := strings.Split(value, "\n")
+	for i, line := range lines {
+		lines[i] = strings.Join(strings.Fields(line), " ")
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,135 @@
+package rfc3464
+
+import (
+	"net/mail"
+	"strings"
+)
+
+// Address types registered for Original-Recipient and Final-Recipient fields
+const (
+	AddressTypeRFC822  = "rfc822"
+	AddressTypeUTF8    = "utf-8"
+	AddressTypeX400    = "x400"
+	AddressTypeUnknown = "unknown"
+)
+
+/*
+Address represents recipient address of Original-Recipient
+or Final-Recipient field
+
+	address-type ";" generic-address
+*/
+type Address struct {
+	// Type is lower-cased address-type sub-field.
+	// Missing address type is reported as "rfc822".
+	Type string
+
+	// Raw is generic-address sub-field as present in field
+	Raw string
+
+	// Address is decoded recipient address.
+	// For rfc822 and utf-8 types it is bare mailbox with lower-cased domain,
+	// e.g. "User@example.com" for "<User@EXAMPLE.COM>".
+	Address string
+}
+
+// String returns decoded address
+func (a Address) String() string {
+	return a.Address
+}
+
+// Domain returns domain part of rfc822 and utf-8 address
+func (a Address) Domain() string {
+	if a.Type != AddressTypeRFC822 && a.Type != AddressTypeUTF8 {
+		return ""
+	}
+
+	if i := strings.LastIndexByte(a.Address, '@'); i >= 0 {
+		return a.Address[i+1:]
+	}
+	return ""
+}
+
+// ParseAddress parses recipient address from field value.
+// When xtext is true generic-address is decoded as RFC3461 xtext
+// the way Original-Recipient is supplied by ORCPT parameter.
+//
+// Address with Type and Raw set is returned along with error
+// when generic-address is malformed.
+func ParseAddress(field TypeValueField, xtext bool) (Address, error) {
+	return parseAddress(field, xtext, false)
+}
+
+// parseAddress parses recipient address. When decoded is true value of
+// "utf-8" address was decoded by parser already and is not decoded again.
+func parseAddress(field TypeValueField, xtext, decoded bool) (Address, error) {
+	addr := Address{
+		Type: strings.ToLower(field.Type),
+		Raw:  field.Value,
+	}
+
+	if addr.Type == "" {
+		addr.Type = AddressTypeRFC822
+	}
+
+	if field.Value == "" {
+		return addr, ErrorFieldNotPresent
+	}
+
+	value := field.Value
+
+	switch addr.Type {
+	case AddressTypeRFC822, AddressTypeUTF8:
+		var err error
+
+		if addr.Type == AddressTypeUTF8 {
+			if !decoded {
+				value, err = DecodeUTF8Address(value)
+			}
+		} else if xtext {
+			// values which are not valid xtext are taken literally,
+			// many MTAs do not encode original recipient
+			if decoded, err := DecodeXtext(value); err == nil {
+				value = decoded
+			}
+		}
+
+		if err != nil {
+			return addr, err
+		}
+
+		mailbox, err := normalizeMailbox(value)
+		if err != nil {
+			return addr, err
+		}
+
+		addr.Address = mailbox
+	default:
+		if xtext {
+			if decoded, err := DecodeXtext(value); err == nil {
+				value = decoded
+			}
+		}
+
+		addr.Address = value
+	}
+
+	return addr, nil
+}
+
+// normalizeMailbox validates mailbox and returns addr-spec
+// with lower-cased domain
+func normalizeMailbox(value string) (string, error) {
+	parsed, err := mail.ParseAddress(value)
+	if err != nil {
+		return "", ErrorInvalidAddress
+	}
+
+	mailbox := parsed.Address
+
+	if i := strings.LastIndexByte(mailbox, '@'); i >= 0 {
+		mailbox = mailbox[:i+1] + strings.ToLower(mailbox[i+1:])
+	}
+
+	return mailbox, nil
+}
@@ -0,0 +1,145 @@
+package rfc3464
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseAddress(t *testing.T) {
+	type fixture struct {
+		field         TypeValueField
+		xtext         bool
+		expected      Address
+		expectedError error
+	}
+
+	fixtures := []fixture{
+		fixture{
+			field:    TypeValueField{Type: "rfc822", Value: "User@EXAMPLE.com"},
+			expected: Address{Type: "rfc822", Raw: "User@EXAMPLE.com", Address: "User@example.com"},
+		},
+		fixture{
+			field:    TypeValueField{Type: "RFC822", Value: "<user@example.com>"},
+			expected: Address{Type: "rfc822", Raw: "<user@example.com>", Address: "user@example.com"},
+		},
+		fixture{
+			field:    TypeValueField{Type: "rfc822", Value: "user+2Btag@example.com"},
+			xtext:    true,
+			expected: Address{Type: "rfc822", Raw: "user+2Btag@example.com", Address: "user+tag@example.com"},
+		},
+		fixture{
+			field:    TypeValueField{Type: "rfc822", Value: "user+tag@example.com"},
+			xtext:    true,
+			expected: Address{Type: "rfc822", Raw: "user+tag@example.com", Address: "user+tag@example.com"},
+		},
+		fixture{
+			field:    TypeValueField{Type: "rfc822", Value: "user+2Btag@example.com"},
+			expected: Address{Type: "rfc822", Raw: "user+2Btag@example.com", Address: "user+2Btag@example.com"},
+		},
+		fixture{
+			field:    TypeValueField{Value: "user@example.com"},
+			expected: Address{Type: "rfc822", Raw: "user@example.com", Address: "user@example.com"},
+		},
+		fixture{
+			field:    TypeValueField{Type: "utf-8", Value: `\x{44F}@ПРИМЕР.рф`},
+			expected: Address{Type: "utf-8", Raw: `\x{44F}@ПРИМЕР.рф`, Address: "я@пример.рф"},
+		},
+		fixture{
+			field:    TypeValueField{Type: "x400", Value: "/G=John/S=Smith/O=Example/C=US/"},
+			expected: Address{Type: "x400", Raw: "/G=John/S=Smith/O=Example/C=US/", Address: "/G=John/S=Smith/O=Example/C=US/"},
+		},
+		fixture{
+			field:    TypeValueField{Type: "unknown", Value: "+2Fsomething"},
+			xtext:    true,
+			expected: Address{Type: "unknown", Raw: "+2Fsomething", Address: "/something"},
+		},
+		fixture{
+			field:         TypeValueField{Type: "rfc822", Value: "not an address"},
+			expected:      Address{Type: "rfc822", Raw: "not an address"},
+			expectedError: ErrorInvalidAddress,
+		},
+		fixture{
+			field:         TypeValueField{Type: "utf-8", Value: `\x{ZZ}@example.com`},
+			expected:      Address{Type: "utf-8", Raw: `\x{ZZ}@example.com`},
+			expectedError: ErrorInvalidAddressEncoding,
+		},
+		fixture{
+			field:         TypeValueField{Type: "rfc822"},
+			expected:      Address{Type: "rfc822"},
+			expectedError: ErrorFieldNotPresent,
+		},
+	}
+
+	for _, f := range fixtures {
+		got, err := ParseAddress(f.field, f.xtext)
+
+		assert.Equal(t, f.expectedError, err, "Fixture: %#v", f.field)
+		assert.Equal(t, f.expected, got, "Fixture: %#v", f.field)
+	}
+}
+
+func Test_Address_Domain(t *testing.T) {
+	assert.Equal(t, "example.com", Address{Type: "rfc822", Address: "user@example.com"}.Domain())
+	assert.Equal(t, "пример.рф", Address{Type: "utf-8", Address: "я@пример.рф"}.Domain())
+	assert.Equal(t, "", Address{Type: "x400", Address: "/O=a@b/"}.Domain())
+	assert.Equal(t, "user@example.com", Address{Address: "user@example.com"}.String())
+}
+
+func Test_RecipientRecord_Recipient(t *testing.T) {
+	record := RecipientRecord{
+		OriginalRecipient: TypeValueField{Type: "rfc822", Value: "alias+2Bx@example.com"},
+		FinalRecipient:    TypeValueField{Type: "rfc822", Value: "mailbox@example.com"},
+	}
+
+	addr, err := record.Recipient()
+	assert.NoError(t, err)
+	assert.Equal(t, "mailbox@example.com", addr.Address)
+
+	addr, err = record.OriginalAddress()
+	assert.NoError(t, err)
+	assert.Equal(t, "alias+x@example.com", addr.Address)
+
+	record.FinalRecipient = TypeValueField{}
+	addr, err = record.Recipient()
+	assert.NoError(t, err)
+	assert.Equal(t, "alias+x@example.com", addr.Address)
+
+	record.FinalRecipient = TypeValueField{Type: "rfc822", Value: "broken"}
+	addr, err = record.Recipient()
+	assert.NoError(t, err)
+	assert.Equal(t, "alias+x@example.com", addr.Address)
+
+	record.OriginalRecipient = TypeValueField{}
+	_, err = record.Recipient()
+	assert.Equal(t, ErrorInvalidAddress, err)
+
+	record = RecipientRecord{}
+	_, err = record.Recipient()
+	assert.Equal(t, ErrorFieldNotPresent, err)
+}
+
+func Test_RecipientRecord_UTF8AddressDecodedOnce(t *testing.T) {
+	data := "Reporting-MTA: dns; mx.example.com\r\n\r\n" +
+		"Original-Recipient: utf-8; \\x{44F}@example.com\r\n" +
+		"Final-Recipient: utf-8; \\x{5C}x{44F}@example.com\r\n"
+
+	dsn, err := (&walker{}).parseReport(strings.NewReader(data))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	record := dsn.Recipients[0]
+	assert.Equal(t, "я@example.com", record.OriginalRecipient.Value)
+	assert.Equal(t, `\x{44F}@example.com`, record.FinalRecipient.Value)
+
+	addr, err := record.OriginalAddress()
+	assert.NoError(t, err)
+	assert.Equal(t, "я@example.com", addr.Address)
+
+	// literal "\x{44F}" left by the first decoding is not decoded again
+	addr, err = record.FinalAddress()
+	assert.Equal(t, ErrorInvalidAddress, err)
+	assert.Equal(t, "", addr.Address)
+}
@@ -0,0 +1,74 @@
+package rfc3464
+
+import (
+	"net/textproto"
+
+	"github.com/YouDoCom/go-maildsnparsers/internal/rfc822"
+)
+
+/*
+Comments represents RFC822 comments removed from structured fields
+of DSN and RecipientRecord, keyed by canonical field name.
+
+	Comments of the form "(comment text)" may appear in structured
+	DSN fields, e.g. "Action: failed (permanent error)" or
+	"Reporting-MTA: dns; mx.example.com (Postfix)".
+
+Comments are kept as written, without enclosing parentheses.
+Unstructured fields Original-Envelope-Id, Diagnostic-Code, Final-Log-ID
+and extension fields are not stripped of comments. Generic-address of
+Original-Recipient and Final-Recipient is *text too, so only its
+address-type is stripped of comments.
+*/
+type Comments map[string][]string
+
+// Get returns comments of the field, name is case-insensitive
+func (c Comments) Get(name string) []string {
+	if c == nil {
+		return nil
+	}
+	return c[textproto.CanonicalMIMEHeaderKey(name)]
+}
+
+// strip removes comments from value of the field with canonical key
+// and stores them in c
+func (c Comments) strip(key, value string) string {
+	value, comments := SplitComments(value)
+	c.add(key, comments)
+	return value
+}
+
+// stripAddress parses address field with canonical key
+// and stores comments removed from its address-type in c
+func (c Comments) stripAddress(key, value string) TypeValueField {
+	field, comments := parseAddressField(value)
+	c.add(key, comments)
+	return field
+}
+
+func (c Comments) add(key string, comments []string) {
+	if len(comments) > 0 {
+		c[key] = append(c[key], comments...)
+	}
+}
+
+// parseAddressField parses value of Original-Recipient or Final-Recipient
+// field, see rfc822.SplitAddressField
+func parseAddressField(value string) (TypeValueField, []string) {
+	addressType, address, comments := rfc822.SplitAddressField(value)
+	return TypeValueField{Type: addressType, Value: address}, comments
+}
+
+// SplitComments removes RFC822 comments from structured field value.
+// Value is returned with runs of white space replaced by single space
+// and comments without enclosing parentheses. Nested comments are kept
+// inside enclosing comment, parentheses in quoted strings are not comments.
+// Line breaks separating values of repeated fields are kept.
+func SplitComments(value string) (string, []string) {
+	return rfc822.SplitComments(value)
+}
+
+// stripComments removes RFC822 comments enclosed in parentheses
+func stripComments(value string) string {
+	return rfc822.StripComments(value)
+}
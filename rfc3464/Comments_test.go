@@ -0,0 +1,115 @@
+package rfc3464
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SplitComments(t *testing.T) {
+	type fixture struct {
+		value    string
+		expected string
+		comments []string
+	}
+
+	fixtures := []fixture{
+		fixture{value: "failed", expected: "failed"},
+		fixture{value: "failed (permanent error)", expected: "failed", comments: []string{"permanent error"}},
+		fixture{value: "  dns;\tmx.example.com   (Postfix) ", expected: "dns; mx.example.com", comments: []string{"Postfix"}},
+		fixture{value: "5.0.0 (a (nested) comment)(second)", expected: "5.0.0", comments: []string{"a (nested) comment", "second"}},
+		fixture{value: `rfc822; "odd (user)"@example.com`, expected: `rfc822; "odd (user)"@example.com`},
+		fixture{value: `a (escaped \) paren) b`, expected: "a b", comments: []string{`escaped \) paren`}},
+		fixture{value: "value (unterminated", expected: "value", comments: []string{"unterminated"}},
+		fixture{value: "value ()", expected: "value"},
+		fixture{value: "one (1)\ntwo  (2)", expected: "one\ntwo", comments: []string{"1", "2"}},
+	}
+
+	for _, f := range fixtures {
+		value, comments := SplitComments(f.value)
+
+		assert.Equal(t, f.expected, value, "Fixture: %q", f.value)
+		assert.Equal(t, f.comments, comments, "Fixture: %q", f.value)
+	}
+}
+
+func Test_Comments_Parse(t *testing.T) {
+	report := strings.Join([]string{
+		"Reporting-MTA: dns; mx.example.com (Postfix)",
+		"Arrival-Date: Thu, 7 Jul 1994 17:15:49 -0400 (EDT)",
+		"Original-Envelope-Id: id (not a comment)",
+		"",
+		"Original-Recipient: x400 (gateway); /G=John/S=Doe(Sales)/O=Org/",
+		"Final-Recipient: rfc822 (smtp);  user@example.com (primary)",
+		"Action: failed (permanent error)",
+		"Status: 5.0.0 (undefined status)",
+		"Diagnostic-Code: smtp; 550 5.0.0 (kept as is)",
+		"X-Extension: value (kept as is)",
+		"",
+	}, "\r\n")
+
+	dsn, err := (&walker{}).parseReport(bytes.NewReader([]byte(report)))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, TypeValueField{Type: "dns", Value: "mx.example.com"}, dsn.ReportingMTA)
+	assert.Equal(t, []string{"Postfix"}, dsn.Comments.Get("Reporting-MTA"))
+	assert.Equal(t, "Thu, 7 Jul 1994 17:15:49 -0400", dsn.ArrivalDate)
+	assert.Equal(t, []string{"EDT"}, dsn.Comments.Get("arrival-date"))
+	assert.Equal(t, "id (not a comment)", dsn.OriginalEnvelopeID)
+
+	record := dsn.Recipients[0]
+	assert.Equal(t, TypeValueField{Type: "x400", Value: "/G=John/S=Doe(Sales)/O=Org/"}, record.OriginalRecipient)
+	assert.Equal(t, []string{"gateway"}, record.Comments.Get("Original-Recipient"))
+	assert.Equal(t, TypeValueField{Type: "rfc822", Value: "user@example.com (primary)"}, record.FinalRecipient)
+	assert.Equal(t, []string{"smtp"}, record.Comments.Get("Final-Recipient"))
+
+	addr, err := record.FinalAddress()
+	if assert.NoError(t, err) {
+		assert.Equal(t, "user@example.com", addr.Address)
+	}
+	assert.Equal(t, RecipientAction("failed"), record.Action)
+	assert.True(t, record.Action.IsFailed())
+	assert.Equal(t, "5.0.0", record.Status)
+	assert.Equal(t, "smtp; 550 5.0.0 (kept as is)", record.DiagnosticCode.String())
+	assert.Equal(t, "value (kept as is)", record.Extensions.Get("X-Extension"))
+
+	status, err := record.EnhancedStatus()
+	if assert.NoError(t, err) {
+		assert.Equal(t, "undefined status", status.Comment)
+	}
+
+	assert.Len(t, Validate(dsn), 1) // report-type is not set
+}
+
+func Test_parseAddressField(t *testing.T) {
+	type fixture struct {
+		value    string
+		expected TypeValueField
+		comments []string
+	}
+
+	fixtures := []fixture{
+		fixture{value: "x400; /G=John/S=Doe(Sales)/O=Org/", expected: TypeValueField{Type: "x400", Value: "/G=John/S=Doe(Sales)/O=Org/"}},
+		fixture{value: " rfc822 (original) ; user@example.com ", expected: TypeValueField{Type: "rfc822", Value: "user@example.com"}, comments: []string{"original"}},
+		fixture{value: "user@example.com (no type)", expected: TypeValueField{Value: "user@example.com (no type)"}},
+	}
+
+	for _, f := range fixtures {
+		field, comments := parseAddressField(f.value)
+
+		assert.Equal(t, f.expected, field, "Fixture: %q", f.value)
+		assert.Equal(t, f.comments, comments, "Fixture: %q", f.value)
+	}
+}
+
+func Test_Comments_Get(t *testing.T) {
+	var empty Comments
+	assert.Nil(t, empty.Get("Status"))
+
+	comments := Comments{"Reporting-Mta": []string{"Postfix"}}
+	assert.Equal(t, []string{"Postfix"}, comments.Get("REPORTING-MTA"))
+}
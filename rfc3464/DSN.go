@@ -3,6 +3,7 @@ package rfc3464
 import (
 	"net/textproto"
 	"strings"
+	"time"
 )
 
 /*
@@ -254,10 +255,42 @@ type DSN struct {
 		"X-", (e.g., "X-Foomail-Log-ID").
 	*/
 	Extensions Extensions
+
+	// Global indicates that report was RFC6533 message/global-delivery-status,
+	// so field values may contain UTF-8 characters
+	Global bool
+
+	// HumanReadable is decoded UTF-8 text of the first part of multipart/report
+	// intended for human reader. It often explains the failure better
+	// than generic machine-readable fields.
+	HumanReadable string
+
+	// ReturnedMessage is original message or its header returned in the
+	// third part of multipart/report. It is nil if report has no such part.
+	ReturnedMessage *ReturnedMessage
+
+	// Path is location of multipart/report entity in parsed message.
+	// Empty path means that message itself is a report.
+	Path PartPath
+
+	// ReportType is report-type parameter of multipart/report Content-Type
+	ReportType string
+
+	// Errors lists malformed blocks skipped in ContinueOnError mode
+	Errors []*RecordError
+
+	// Fields lists per-message fields as written in report, in original order.
+	// It is empty if DSN was not parsed from delivery-status part.
+	Fields Fields
+
+	// Comments removed from structured per-message fields,
+	// nil if there are no comments
+	Comments Comments
 }
 
 func (dsn *DSN) fillFromHeader(hdr textproto.MIMEHeader) {
 	dsn.Extensions = make(Extensions)
+	comments := make(Comments)
 
 	var (
 		keyOriginalEnvelopeID = textproto.CanonicalMIMEHeaderKey("Original-Envelope-Id")
@@ -268,21 +301,42 @@ func (dsn *DSN) fillFromHeader(hdr textproto.MIMEHeader) {
 	)
 
 	for k, v := range hdr {
-		val := strings.Join(v, "\n")
+		val := v[0]
 
 		switch k {
 		case keyOriginalEnvelopeID:
 			dsn.OriginalEnvelopeID = val
 		case keyReportingMTA:
-			dsn.ReportingMTA = ParseTypeValueField(val)
+			dsn.ReportingMTA = ParseTypeValueField(comments.strip(k, val))
 		case keyDsnGateway:
-			dsn.DsnGateway = ParseTypeValueField(val)
+			dsn.DsnGateway = ParseTypeValueField(comments.strip(k, val))
 		case keyReceivedFromMTA:
-			dsn.ReceivedFromMTA = ParseTypeValueField(val)
+			dsn.ReceivedFromMTA = ParseTypeValueField(comments.strip(k, val))
 		case keyArrivalDate:
-			dsn.ArrivalDate = val
+			dsn.ArrivalDate = comments.strip(k, val)
 		default:
-			dsn.Extensions.Set(k, val)
+			dsn.Extensions.Set(k, strings.Join(v, "\n"))
 		}
 	}
+
+	if len(comments) > 0 {
+		dsn.Comments = comments
+	}
+}
+
+func (dsn *DSN) fillFromFields(fields Fields) {
+	dsn.Fields = fields
+	dsn.fillFromHeader(fields.Header())
+}
+
+// RepeatedFields returns names of single-instance per-message fields,
+// which appear in report more than once. Only the first one is parsed.
+func (dsn *DSN) RepeatedFields() []string {
+	return repeatedFields(dsn.Fields, perMessageFieldNames)
+}
+
+// ArrivalTime parses Arrival-Date field.
+// ErrorFieldNotPresent is returned when field is empty.
+func (dsn *DSN) ArrivalTime() (time.Time, error) {
+	return parseDateField(dsn.ArrivalDate)
 }
@@ -0,0 +1,296 @@
+package rfc3464
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+/*
+ParseDateTime parses RFC5322 date-time used by Arrival-Date,
+Last-Attempt-Date and Will-Retry-Until fields
+
+	date-time       =   [ day-of-week "," ] date time [CFWS]
+	date            =   day month year
+	time            =   time-of-day zone
+	time-of-day     =   hour ":" minute [ ":" second ]
+	zone            =   (FWS ( "+" / "-" ) 4DIGIT) / obs-zone
+
+Obsolete syntax is accepted: alphabetic zones ("UT", "GMT", "EST", "PDT",
+military letters), two and three digit years, missing seconds and comments.
+Military zones are treated as "-0000" as recommended by RFC5322.
+Day and month names are accepted abbreviated or in full, other words
+and repeated month or zone are rejected.
+RFC3339 timestamps and ANSI C asctime() dates are accepted too.
+*/
+func ParseDateTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(stripComments(value))
+
+	if value == "" {
+		return time.Time{}, ErrorInvalidDateTime
+	}
+
+	for _, layout := range fallbackDateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+
+	return parseObsDateTime(value)
+}
+
+var fallbackDateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05 -0700",
+}
+
+// monthNames contains abbreviated and full month names
+var monthNames = map[string]time.Month{
+	"jan": time.January, "feb": time.February, "mar": time.March,
+	"apr": time.April, "may": time.May, "jun": time.June,
+	"jul": time.July, "aug": time.August, "sep": time.September,
+	"oct": time.October, "nov": time.November, "dec": time.December,
+	"january": time.January, "february": time.February, "march": time.March,
+	"april": time.April, "june": time.June, "july": time.July,
+	"august": time.August, "september": time.September, "october": time.October,
+	"november": time.November, "december": time.December,
+}
+
+// dayNames contains abbreviated and full day names
+var dayNames = map[string]bool{
+	"mon": true, "tue": true, "wed": true, "thu": true,
+	"fri": true, "sat": true, "sun": true,
+	"monday": true, "tuesday": true, "wednesday": true, "thursday": true,
+	"friday": true, "saturday": true, "sunday": true,
+}
+
+// zoneOffsets contains obs-zone values in hours
+var zoneOffsets = map[string]int{
+	"UT": 0, "UTC": 0, "GMT": 0, "Z": 0,
+	"EST": -5, "EDT": -4,
+	"CST": -6, "CDT": -5,
+	"MST": -7, "MDT": -6,
+	"PST": -8, "PDT": -7,
+}
+
+// isMilitaryZone reports whether zone is obs-zone military letter
+func isMilitaryZone(zone string) bool {
+	return len(zone) == 1 && zone[0] >= 'A' && zone[0] <= 'Z' && zone[0] != 'J'
+}
+
+// parseObsDateTime parses date-time token by token tolerating order
+// of the fields and obsolete syntax
+func parseObsDateTime(value string) (time.Time, error) {
+	var (
+		numbers            []string
+		month              time.Month
+		hour, min, sec     = -1, 0, 0
+		pm, am             bool
+		zoneName           string
+		offset             int
+		haveZone, haveTime bool
+		haveZoneWord       bool
+		haveDay            bool
+	)
+
+	tokens := strings.Fields(strings.Replace(value, ",", " ", -1))
+
+	for _, tok := range tokens {
+		if (tok[0] == '+' || tok[0] == '-') && len(tok) == 6 && tok[3] == ':' {
+			tok = tok[:3] + tok[4:]
+		}
+
+		switch {
+		case strings.Contains(tok, ":"):
+			if haveTime {
+				return time.Time{}, ErrorInvalidDateTime
+			}
+			var ok bool
+			if hour, min, sec, ok = parseTimeOfDay(tok); !ok {
+				return time.Time{}, ErrorInvalidDateTime
+			}
+			haveTime = true
+		case (tok[0] == '+' || tok[0] == '-') && len(tok) == 5 && isDigits(tok[1:]):
+			h, _ := strconv.Atoi(tok[1:3])
+			m, _ := strconv.Atoi(tok[3:5])
+			if m > 59 {
+				return time.Time{}, ErrorInvalidDateTime
+			}
+			if haveZone {
+				return time.Time{}, ErrorInvalidDateTime
+			}
+			offset = (h*60 + m) * 60
+			if tok[0] == '-' {
+				offset = -offset
+			}
+			haveZone = true
+		case isDigits(tok):
+			numbers = append(numbers, tok)
+		case isAlpha(tok):
+			lower := strings.ToLower(tok)
+			upper := strings.ToUpper(tok)
+
+			if dayNames[lower] {
+				if haveDay {
+					return time.Time{}, ErrorInvalidDateTime
+				}
+				haveDay = true
+				continue
+			}
+			if monthNames[lower] != 0 {
+				if month != 0 {
+					return time.Time{}, ErrorInvalidDateTime
+				}
+				month = monthNames[lower]
+				continue
+			}
+			if upper == "AM" || upper == "PM" {
+				am, pm = upper == "AM", upper == "PM"
+				continue
+			}
+			// zone follows time-of-day, other words are not allowed
+			if !haveTime || haveZoneWord {
+				return time.Time{}, ErrorInvalidDateTime
+			}
+			haveZoneWord = true
+
+			h, known := zoneOffsets[upper]
+			if !known && !isMilitaryZone(upper) {
+				return time.Time{}, ErrorInvalidDateTime
+			}
+			if haveZone {
+				// zone name after numeric zone, e.g. "-0700 PDT"
+				if !known {
+					return time.Time{}, ErrorInvalidDateTime
+				}
+				continue
+			}
+			if known {
+				zoneName, offset = upper, h*3600
+			}
+			// military zones are treated as "-0000" (unknown local time)
+			haveZone = true
+		default:
+			return time.Time{}, ErrorInvalidDateTime
+		}
+	}
+
+	if !haveTime || month == 0 || len(numbers) != 2 {
+		return time.Time{}, ErrorInvalidDateTime
+	}
+
+	dayStr, yearStr := numbers[0], numbers[1]
+	if len(dayStr) > 2 {
+		dayStr, yearStr = yearStr, dayStr
+	}
+
+	day, _ := strconv.Atoi(dayStr)
+	year, _ := strconv.Atoi(yearStr)
+
+	switch len(yearStr) {
+	case 1, 2:
+		if year < 50 {
+			year += 2000
+		} else {
+			year += 1900
+		}
+	case 3:
+		year += 1900
+	}
+
+	if len(dayStr) > 2 || day < 1 || day > daysIn(month, year) {
+		return time.Time{}, ErrorInvalidDateTime
+	}
+
+	if am || pm {
+		if hour < 1 || hour > 12 {
+			return time.Time{}, ErrorInvalidDateTime
+		}
+		hour %= 12
+		if pm {
+			hour += 12
+		}
+	}
+
+	loc := time.UTC
+	if offset != 0 {
+		loc = time.FixedZone(zoneName, offset)
+	}
+
+	return time.Date(year, month, day, hour, min, sec, 0, loc), nil
+}
+
+// parseTimeOfDay parses hour ":" minute [ ":" second ]
+func parseTimeOfDay(value string) (hour, min, sec int, ok bool) {
+	parts := strings.Split(value, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, 0, 0, false
+	}
+
+	if len(parts) == 3 {
+		// ignore fractional seconds
+		if i := strings.IndexByte(parts[2], '.'); i >= 0 {
+			parts[2] = parts[2][:i]
+		}
+	} else {
+		parts = append(parts, "0")
+	}
+
+	var nums [3]int
+	for i, p := range parts {
+		if p == "" || len(p) > 2 || !isDigits(p) {
+			return 0, 0, 0, false
+		}
+		nums[i], _ = strconv.Atoi(p)
+	}
+
+	hour, min, sec = nums[0], nums[1], nums[2]
+
+	// leap second is clamped to the last second of minute
+	if sec == 60 {
+		sec = 59
+	}
+
+	if hour > 23 || min > 59 || sec > 59 {
+		return 0, 0, 0, false
+	}
+
+	return hour, min, sec, true
+}
+
+func daysIn(m time.Month, year int) int {
+	return time.Date(year, m+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlpha(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+func parseDateField(value string) (time.Time, error) {
+	if strings.TrimSpace(value) == "" {
+		return time.Time{}, ErrorFieldNotPresent
+	}
+
+	return ParseDateTime(value)
+}
@@ -0,0 +1,144 @@
+package rfc3464
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseDateTime(t *testing.T) {
+	type fixture struct {
+		value    string
+		expected time.Time
+	}
+
+	fixtures := []fixture{
+		fixture{
+			value:    "Thu, 7 Jul 1994 17:15:49 -0400",
+			expected: time.Date(1994, time.July, 7, 21, 15, 49, 0, time.UTC),
+		},
+		fixture{
+			value:    "Mon, 05 Dec 2016 20:08:12 +0300 (MSK)",
+			expected: time.Date(2016, time.December, 5, 17, 8, 12, 0, time.UTC),
+		},
+		fixture{
+			value:    "5 Dec 2016 09:08 -0800",
+			expected: time.Date(2016, time.December, 5, 17, 8, 0, 0, time.UTC),
+		},
+		fixture{
+			value:    "Mon, 5 Dec 16 09:08:13 PST",
+			expected: time.Date(2016, time.December, 5, 17, 8, 13, 0, time.UTC),
+		},
+		fixture{
+			value:    "Thu, 7 Jul 94 17:15:49 EDT",
+			expected: time.Date(1994, time.July, 7, 21, 15, 49, 0, time.UTC),
+		},
+		fixture{
+			value:    "7 Jul 094 17:15:49 GMT",
+			expected: time.Date(1994, time.July, 7, 17, 15, 49, 0, time.UTC),
+		},
+		fixture{
+			value:    "Thu, 7 Jul 1994 17:15:49 Q",
+			expected: time.Date(1994, time.July, 7, 17, 15, 49, 0, time.UTC),
+		},
+		fixture{
+			value:    "Thursday, 7 July 1994 17:15:49 -0400",
+			expected: time.Date(1994, time.July, 7, 21, 15, 49, 0, time.UTC),
+		},
+		fixture{
+			value:    "Thu Jul  7 17:15:49 1994",
+			expected: time.Date(1994, time.July, 7, 17, 15, 49, 0, time.UTC),
+		},
+		fixture{
+			value:    "Thu, 7 Jul 1994 05:15:49 PM +03:00",
+			expected: time.Date(1994, time.July, 7, 14, 15, 49, 0, time.UTC),
+		},
+		fixture{
+			value:    "Thu, 7 Jul 1994 17:15:49 -0400 EDT",
+			expected: time.Date(1994, time.July, 7, 21, 15, 49, 0, time.UTC),
+		},
+		fixture{
+			value:    "1994-07-07T17:15:49-04:00",
+			expected: time.Date(1994, time.July, 7, 21, 15, 49, 0, time.UTC),
+		},
+		fixture{
+			value:    "Thu, 7 Jul 1994 17:15:60 +0000",
+			expected: time.Date(1994, time.July, 7, 17, 15, 59, 0, time.UTC),
+		},
+	}
+
+	for _, f := range fixtures {
+		got, err := ParseDateTime(f.value)
+
+		if assert.NoError(t, err, "Fixture: %q", f.value) {
+			assert.True(t, f.expected.Equal(got), "Fixture: %q, got %v", f.value, got)
+		}
+	}
+}
+
+func Test_ParseDateTime_Zone(t *testing.T) {
+	got, err := ParseDateTime("Mon, 5 Dec 2016 09:08:13 PDT")
+
+	assert.NoError(t, err)
+
+	name, offset := got.Zone()
+	assert.Equal(t, "PDT", name)
+	assert.Equal(t, -7*3600, offset)
+}
+
+func Test_ParseDateTime_Invalid(t *testing.T) {
+	fixtures := []string{
+		"",
+		"(comment only)",
+		"woot",
+		"Thu, 7 Jul 1994",
+		"Thu, 32 Jul 1994 17:15:49 -0400",
+		"Thu, 30 Feb 1994 17:15:49 -0400",
+		"Thu, 7 Jul 1994 25:15:49 -0400",
+		"Thu, 7 1994 17:15:49 -0400",
+		"Thu, 7 Jul 1994 17:15:49 -0460",
+		"Thu, 7 Jul 1994 17:15:49 17:15:49",
+		"garbage 7 Jul 1994 17:15:49",
+		"Thu, 7 Jul 1994 17:15:49 -0400 garbage",
+		"Thu, 7 Jul 1994 17:15:49 PDT EST",
+		"7 Jul 1994 17:15:49 garbage",
+		"7 Jul Aug 1994 17:15:49 +0000",
+		"Thu, 7 Jul 1994 17:15:49 +0400 +0500",
+		"Mayday, 7 May 1994 17:15:49 +0000",
+		"Thu, 7 Mayday 1994 17:15:49 +0000",
+		"Thu, 7 Julember 1994 17:15:49 +0000",
+		"Thu Thu, 7 Jul 1994 17:15:49 +0000",
+		"Thu, 7 Jul 1994 17:15:49 J",
+	}
+
+	for _, f := range fixtures {
+		_, err := ParseDateTime(f)
+
+		assert.Equal(t, ErrorInvalidDateTime, err, "Fixture: %q", f)
+	}
+}
+
+func Test_DateFieldAccessors(t *testing.T) {
+	dsn := DSN{ArrivalDate: "Thu, 7 Jul 1994 17:15:49 -0401"}
+
+	got, err := dsn.ArrivalTime()
+	assert.NoError(t, err)
+	assert.True(t, time.Date(1994, time.July, 7, 21, 16, 49, 0, time.UTC).Equal(got))
+
+	record := RecipientRecord{
+		LastAttemptDate: "Thu, 7 Jul 1994 17:15:49 -0400",
+		WillRetryUntil:  "not a date",
+	}
+
+	got, err = record.LastAttemptTime()
+	assert.NoError(t, err)
+	assert.True(t, time.Date(1994, time.July, 7, 21, 15, 49, 0, time.UTC).Equal(got))
+
+	_, err = record.WillRetryUntilTime()
+	assert.Equal(t, ErrorInvalidDateTime, err)
+
+	record = RecipientRecord{}
+	_, err = record.LastAttemptTime()
+	assert.Equal(t, ErrorFieldNotPresent, err)
+}
@@ -0,0 +1,178 @@
+package rfc3464
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+/*
+StatusClass represents class sub-field of RFC3463 enhanced status code
+
+	class = "2"/"4"/"5"
+
+	2.XXX.XXX   Success
+	4.XXX.XXX   Persistent Transient Failure
+	5.XXX.XXX   Permanent Failure
+*/
+type StatusClass int
+
+const (
+	// StatusClassSuccess indicates that the DSN is reporting a positive delivery action
+	StatusClassSuccess StatusClass = 2
+
+	// StatusClassTransient indicates that the message as sent is valid,
+	// but persistence of some temporary condition has caused abandonment or delay
+	StatusClassTransient StatusClass = 4
+
+	// StatusClassPermanent indicates that the error is not likely to be resolved
+	// by resending the message in the current form
+	StatusClassPermanent StatusClass = 5
+)
+
+// String returns human readable name of status class
+func (c StatusClass) String() string {
+	switch c {
+	case StatusClassSuccess:
+		return "Success"
+	case StatusClassTransient:
+		return "Persistent Transient Failure"
+	case StatusClassPermanent:
+		return "Permanent Failure"
+	}
+
+	return fmt.Sprintf("StatusClass(%d)", int(c))
+}
+
+// IsValid checks that class is one of defined by RFC3463
+func (c StatusClass) IsValid() bool {
+	return c == StatusClassSuccess || c == StatusClassTransient || c == StatusClassPermanent
+}
+
+/*
+EnhancedStatus represents parsed RFC3463 enhanced mail system status code
+
+	status-code = class "." subject "." detail
+
+	subject = 1*3digit
+	detail = 1*3digit
+
+	White-space characters and comments are NOT allowed within
+	a status-code, though a comment enclosed in parentheses
+	MAY follow the last numeric sub-field of the status-code.
+	Each numeric sub-field within the status-code MUST be
+	expressed without leading zero digits.
+*/
+type EnhancedStatus struct {
+	// Class sub-field
+	Class StatusClass
+	// Subject sub-field
+	Subject int
+	// Detail sub-field
+	Detail int
+	// Comment following the status code, without parentheses
+	Comment string
+}
+
+// ParseEnhancedStatus parses RFC3463 enhanced status code from string.
+// Trailing comment (e.g. "5.1.1 (bad destination mailbox)") is stored in Comment.
+func ParseEnhancedStatus(value string) (EnhancedStatus, error) {
+	value = strings.TrimSpace(value)
+
+	code, comment := value, ""
+	if i := strings.IndexAny(value, " \t\r\n("); i >= 0 {
+		code, comment = value[:i], strings.TrimSpace(value[i:])
+	}
+
+	sub := strings.Split(code, ".")
+	if len(sub) != 3 {
+		return EnhancedStatus{}, ErrorInvalidStatus
+	}
+
+	var nums [3]int
+	for i, s := range sub {
+		n, ok := parseStatusNumber(s)
+		if !ok {
+			return EnhancedStatus{}, ErrorInvalidStatus
+		}
+		nums[i] = n
+	}
+
+	status := EnhancedStatus{
+		Class:   StatusClass(nums[0]),
+		Subject: nums[1],
+		Detail:  nums[2],
+		Comment: trimComment(comment),
+	}
+
+	if !status.Class.IsValid() {
+		return EnhancedStatus{}, ErrorInvalidStatus
+	}
+
+	return status, nil
+}
+
+// parseStatusNumber parses 1*3digit without leading zeros
+func parseStatusNumber(s string) (int, bool) {
+	if len(s) == 0 || len(s) > 3 {
+		return 0, false
+	}
+
+	if len(s) > 1 && s[0] == '0' {
+		return 0, false
+	}
+
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return 0, false
+		}
+	}
+
+	n, err := strconv.Atoi(s)
+	return n, err == nil
+}
+
+// trimComment removes enclosing parentheses from comment
+func trimComment(comment string) string {
+	if strings.HasPrefix(comment, "(") && strings.HasSuffix(comment, ")") {
+		comment = comment[1 : len(comment)-1]
+	}
+
+	return strings.TrimSpace(comment)
+}
+
+// String returns status code in "class.subject.detail" form without comment
+func (s EnhancedStatus) String() string {
+	return fmt.Sprintf("%d.%d.%d", int(s.Class), s.Subject, s.Detail)
+}
+
+// IsSuccess indicates that status reports a positive delivery action
+func (s EnhancedStatus) IsSuccess() bool {
+	return s.Class == StatusClassSuccess
+}
+
+// IsTransient indicates persistent transient failure
+func (s EnhancedStatus) IsTransient() bool {
+	return s.Class == StatusClassTransient
+}
+
+// IsPermanent indicates permanent failure
+func (s EnhancedStatus) IsPermanent() bool {
+	return s.Class == StatusClassPermanent
+}
+
+// Info returns registry entry for status code.
+// The second result is false if code is not registered.
+func (s EnhancedStatus) Info() (EnhancedStatusInfo, bool) {
+	return LookupEnhancedStatus(s.Subject, s.Detail)
+}
+
+// Description returns registered description of status code.
+// For unregistered codes description of subject is returned.
+func (s EnhancedStatus) Description() string {
+	if info, ok := s.Info(); ok {
+		return info.Title
+	}
+
+	return subjectDescriptions[s.Subject]
+}
@@ -0,0 +1,161 @@
+package rfc3464
+
+import "fmt"
+
+/*
+EnhancedStatusInfo represents entry of IANA "Enumerated Status Codes" registry
+
+https://www.iana.org/assignments/smtp-enhanced-status-codes
+*/
+type EnhancedStatusInfo struct {
+	// Subject sub-field
+	Subject int
+	// Detail sub-field
+	Detail int
+	// Title is short description of status code
+	Title string
+	// Classes lists status classes code may be used with
+	Classes []StatusClass
+	// Reference is RFC which defines status code
+	Reference string
+}
+
+// Code returns status code in registry form, e.g. "X.1.1"
+func (info EnhancedStatusInfo) Code() string {
+	return fmt.Sprintf("X.%d.%d", info.Subject, info.Detail)
+}
+
+// AppliesTo checks that status code is registered for class
+func (info EnhancedStatusInfo) AppliesTo(class StatusClass) bool {
+	for _, c := range info.Classes {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
+
+// LookupEnhancedStatus returns registry entry for "X.subject.detail" status code.
+// The second result is false if code is not registered.
+func LookupEnhancedStatus(subject, detail int) (EnhancedStatusInfo, bool) {
+	info, ok := enhancedStatusRegistry[[2]int{subject, detail}]
+	return info, ok
+}
+
+var subjectDescriptions = map[int]string{
+	0: "Other or Undefined Status",
+	1: "Addressing Status",
+	2: "Mailbox Status",
+	3: "Mail System Status",
+	4: "Network and Routing Status",
+	5: "Mail Delivery Protocol Status",
+	6: "Message Content or Media Status",
+	7: "Security or Policy Status",
+}
+
+var (
+	classesS   = []StatusClass{StatusClassSuccess}
+	classesT   = []StatusClass{StatusClassTransient}
+	classesP   = []StatusClass{StatusClassPermanent}
+	classesTP  = []StatusClass{StatusClassTransient, StatusClassPermanent}
+	classesSP  = []StatusClass{StatusClassSuccess, StatusClassPermanent}
+	classesSTP = []StatusClass{StatusClassSuccess, StatusClassTransient, StatusClassPermanent}
+)
+
+var enhancedStatusRegistry = func() map[[2]int]EnhancedStatusInfo {
+	entries := []EnhancedStatusInfo{
+		{0, 0, "Other undefined Status", classesSTP, "RFC3463"},
+
+		{1, 0, "Other address status", classesSTP, "RFC3463"},
+		{1, 1, "Bad destination mailbox address", classesP, "RFC3463"},
+		{1, 2, "Bad destination system address", classesTP, "RFC3463"},
+		{1, 3, "Bad destination mailbox address syntax", classesP, "RFC3463"},
+		{1, 4, "Destination mailbox address ambiguous", classesTP, "RFC3463"},
+		{1, 5, "Destination address valid", classesS, "RFC3463"},
+		{1, 6, "Destination mailbox has moved, No forwarding address", classesP, "RFC3463"},
+		{1, 7, "Bad sender's mailbox address syntax", classesP, "RFC3463"},
+		{1, 8, "Bad sender's system address", classesTP, "RFC3463"},
+		{1, 9, "Message relayed to non-compliant mailer", classesS, "RFC3886"},
+		{1, 10, "Recipient address has null MX", classesP, "RFC7505"},
+
+		{2, 0, "Other or undefined mailbox status", classesSTP, "RFC3463"},
+		{2, 1, "Mailbox disabled, not accepting messages", classesTP, "RFC3463"},
+		{2, 2, "Mailbox full", classesTP, "RFC3463"},
+		{2, 3, "Message length exceeds administrative limit", classesP, "RFC3463"},
+		{2, 4, "Mailing list expansion problem", classesTP, "RFC3463"},
+
+		{3, 0, "Other or undefined mail system status", classesSTP, "RFC3463"},
+		{3, 1, "Mail system full", classesTP, "RFC3463"},
+		{3, 2, "System not accepting network messages", classesTP, "RFC3463"},
+		{3, 3, "System not capable of selected features", classesTP, "RFC3463"},
+		{3, 4, "Message too big for system", classesP, "RFC3463"},
+		{3, 5, "System incorrectly configured", classesTP, "RFC3463"},
+		{3, 6, "Requested priority was changed", classesS, "RFC6710"},
+
+		{4, 0, "Other or undefined network or routing status", classesSTP, "RFC3463"},
+		{4, 1, "No answer from host", classesT, "RFC3463"},
+		{4, 2, "Bad connection", classesT, "RFC3463"},
+		{4, 3, "Directory server failure", classesT, "RFC3463"},
+		{4, 4, "Unable to route", classesTP, "RFC3463"},
+		{4, 5, "Mail system congestion", classesT, "RFC3463"},
+		{4, 6, "Routing loop detected", classesT, "RFC3463"},
+		{4, 7, "Delivery time expired", classesTP, "RFC3463"},
+
+		{5, 0, "Other or undefined protocol status", classesSTP, "RFC3463"},
+		{5, 1, "Invalid command", classesP, "RFC3463"},
+		{5, 2, "Syntax error", classesP, "RFC3463"},
+		{5, 3, "Too many recipients", classesTP, "RFC3463"},
+		{5, 4, "Invalid command arguments", classesP, "RFC3463"},
+		{5, 5, "Wrong protocol version", classesTP, "RFC3463"},
+		{5, 6, "Authentication Exchange line is too long", classesP, "RFC4954"},
+
+		{6, 0, "Other or undefined media error", classesSTP, "RFC3463"},
+		{6, 1, "Media not supported", classesP, "RFC3463"},
+		{6, 2, "Conversion required and prohibited", classesP, "RFC3463"},
+		{6, 3, "Conversion required but not supported", classesTP, "RFC3463"},
+		{6, 4, "Conversion with loss performed", classesSP, "RFC3463"},
+		{6, 5, "Conversion Failed", classesTP, "RFC3463"},
+		{6, 6, "Message content not available", classesTP, "RFC4468"},
+		{6, 7, "Non-ASCII addresses not permitted for that sender/recipient", classesP, "RFC6531"},
+		{6, 8, "UTF-8 string reply is required, but not permitted by the SMTP client", classesSTP, "RFC6531"},
+		{6, 9, "UTF-8 header message cannot be transferred to one or more recipients", classesP, "RFC6531"},
+		{6, 10, "UTF-8 string reply is required, but not permitted by the SMTP client", classesSTP, "RFC6531"},
+
+		{7, 0, "Other or undefined security status", classesSTP, "RFC3463"},
+		{7, 1, "Delivery not authorized, message refused", classesP, "RFC3463"},
+		{7, 2, "Mailing list expansion prohibited", classesP, "RFC3463"},
+		{7, 3, "Security conversion required but not possible", classesP, "RFC3463"},
+		{7, 4, "Security features not supported", classesP, "RFC3463"},
+		{7, 5, "Cryptographic failure", classesTP, "RFC3463"},
+		{7, 6, "Cryptographic algorithm not supported", classesTP, "RFC3463"},
+		{7, 7, "Message integrity failure", classesTP, "RFC3463"},
+		{7, 8, "Authentication credentials invalid", classesP, "RFC4954"},
+		{7, 9, "Authentication mechanism is too weak", classesP, "RFC4954"},
+		{7, 10, "Encryption Needed", classesP, "RFC5248"},
+		{7, 11, "Encryption required for requested authentication mechanism", classesP, "RFC4954"},
+		{7, 12, "A password transition is needed", classesT, "RFC4954"},
+		{7, 13, "User Account Disabled", classesP, "RFC5248"},
+		{7, 14, "Trust relationship required", classesP, "RFC5248"},
+		{7, 15, "Priority Level is too low", classesTP, "RFC6710"},
+		{7, 16, "Message is too big for the specified priority", classesTP, "RFC6710"},
+		{7, 17, "Mailbox owner has changed", classesP, "RFC7293"},
+		{7, 18, "Domain owner has changed", classesP, "RFC7293"},
+		{7, 19, "RRVS test cannot be completed", classesP, "RFC7293"},
+		{7, 20, "No passing DKIM signature found", classesP, "RFC7372"},
+		{7, 21, "No acceptable DKIM signature found", classesP, "RFC7372"},
+		{7, 22, "No valid author-matched DKIM signature found", classesP, "RFC7372"},
+		{7, 23, "SPF validation failed", classesP, "RFC7372"},
+		{7, 24, "SPF validation error", classesTP, "RFC7372"},
+		{7, 25, "Reverse DNS validation failed", classesP, "RFC7372"},
+		{7, 26, "Multiple authentication checks failed", classesP, "RFC7372"},
+		{7, 27, "Sender address has null MX", classesP, "RFC7505"},
+		{7, 29, "ARC validation failure", classesP, "RFC8617"},
+		{7, 30, "REQUIRETLS support required", classesP, "RFC8689"},
+	}
+
+	registry := make(map[[2]int]EnhancedStatusInfo, len(entries))
+	for _, e := range entries {
+		registry[[2]int{e.Subject, e.Detail}] = e
+	}
+	return registry
+}()
@@ -0,0 +1,126 @@
+package rfc3464
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseEnhancedStatus(t *testing.T) {
+	type fixture struct {
+		value         string
+		expected      EnhancedStatus
+		expectedError error
+	}
+
+	fixtures := []fixture{
+		fixture{
+			value:    "5.1.1",
+			expected: EnhancedStatus{Class: StatusClassPermanent, Subject: 1, Detail: 1},
+		},
+		fixture{
+			value:    " 4.7.24 ",
+			expected: EnhancedStatus{Class: StatusClassTransient, Subject: 7, Detail: 24},
+		},
+		fixture{
+			value:    "5.1.1 (bad destination mailbox)",
+			expected: EnhancedStatus{Class: StatusClassPermanent, Subject: 1, Detail: 1, Comment: "bad destination mailbox"},
+		},
+		fixture{
+			value:    "5.0.0(undefined status)",
+			expected: EnhancedStatus{Class: StatusClassPermanent, Subject: 0, Detail: 0, Comment: "undefined status"},
+		},
+		fixture{
+			value:    "2.999.100",
+			expected: EnhancedStatus{Class: StatusClassSuccess, Subject: 999, Detail: 100},
+		},
+		fixture{
+			value:         "",
+			expectedError: ErrorInvalidStatus,
+		},
+		fixture{
+			value:         "3.1.1",
+			expectedError: ErrorInvalidStatus,
+		},
+		fixture{
+			value:         "5.01.1",
+			expectedError: ErrorInvalidStatus,
+		},
+		fixture{
+			value:         "5.1.1000",
+			expectedError: ErrorInvalidStatus,
+		},
+		fixture{
+			value:         "5.1",
+			expectedError: ErrorInvalidStatus,
+		},
+		fixture{
+			value:         "5.a.1",
+			expectedError: ErrorInvalidStatus,
+		},
+	}
+
+	for _, f := range fixtures {
+		got, err := ParseEnhancedStatus(f.value)
+
+		assert.Equal(t, f.expectedError, err, "Fixture: %q", f.value)
+		assert.Equal(t, f.expected, got, "Fixture: %q", f.value)
+	}
+}
+
+func Test_EnhancedStatus_Classification(t *testing.T) {
+	s := EnhancedStatus{Class: StatusClassPermanent, Subject: 1, Detail: 1}
+	assert.True(t, s.IsPermanent())
+	assert.False(t, s.IsTransient())
+	assert.False(t, s.IsSuccess())
+	assert.Equal(t, "5.1.1", s.String())
+	assert.Equal(t, "Permanent Failure", s.Class.String())
+
+	s = EnhancedStatus{Class: StatusClassTransient, Subject: 2, Detail: 2}
+	assert.True(t, s.IsTransient())
+	assert.Equal(t, "Persistent Transient Failure", s.Class.String())
+
+	s = EnhancedStatus{Class: StatusClassSuccess}
+	assert.True(t, s.IsSuccess())
+	assert.Equal(t, "Success", s.Class.String())
+
+	assert.Equal(t, "StatusClass(3)", StatusClass(3).String())
+}
+
+func Test_EnhancedStatus_Description(t *testing.T) {
+	s := EnhancedStatus{Class: StatusClassPermanent, Subject: 1, Detail: 1}
+	assert.Equal(t, "Bad destination mailbox address", s.Description())
+
+	info, ok := s.Info()
+	assert.True(t, ok)
+	assert.Equal(t, "X.1.1", info.Code())
+	assert.Equal(t, "RFC3463", info.Reference)
+	assert.True(t, info.AppliesTo(StatusClassPermanent))
+	assert.False(t, info.AppliesTo(StatusClassTransient))
+
+	s = EnhancedStatus{Class: StatusClassPermanent, Subject: 7, Detail: 23}
+	assert.Equal(t, "SPF validation failed", s.Description())
+
+	s = EnhancedStatus{Class: StatusClassPermanent, Subject: 1, Detail: 99}
+	_, ok = s.Info()
+	assert.False(t, ok)
+	assert.Equal(t, "Addressing Status", s.Description())
+
+	s = EnhancedStatus{Class: StatusClassPermanent, Subject: 9, Detail: 9}
+	assert.Equal(t, "", s.Description())
+}
+
+func Test_RecipientRecord_EnhancedStatus(t *testing.T) {
+	record := RecipientRecord{Status: "5.2.2 (mailbox full)"}
+
+	s, err := record.EnhancedStatus()
+
+	assert.NoError(t, err)
+	assert.True(t, s.IsPermanent())
+	assert.Equal(t, "mailbox full", s.Comment)
+	assert.Equal(t, "Mailbox full", s.Description())
+
+	record = RecipientRecord{}
+	_, err = record.EnhancedStatus()
+	assert.Equal(t, ErrorInvalidStatus, err)
+}
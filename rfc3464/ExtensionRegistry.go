@@ -0,0 +1,81 @@
+package rfc3464
+
+import (
+	"net/textproto"
+	"strings"
+	"sync"
+)
+
+// ExtensionParser parses value of extension field into typed value
+type ExtensionParser func(value string) (interface{}, error)
+
+var (
+	extensionParsersMu sync.RWMutex
+	extensionParsers   = map[string]ExtensionParser{
+		"X-Postfix-Queue-Id":   func(v string) (interface{}, error) { return parseQueueID(v) },
+		"X-Postfix-Sender":     func(v string) (interface{}, error) { return parseExtensionAddress(v) },
+		"X-Display-Name":       func(v string) (interface{}, error) { return parseDisplayName(v) },
+		"X-Supplementary-Info": func(v string) (interface{}, error) { return ParseSupplementaryInfo(v) },
+		"X-Actual-Recipient":   func(v string) (interface{}, error) { return parseExtensionAddress(v) },
+		"X-Exim-Diagnostic":    func(v string) (interface{}, error) { return parseExtensionDiagnostic(v) },
+	}
+)
+
+/*
+RegisterExtension registers parser for extension field name.
+Name is case-insensitive, parser registered earlier for the name is replaced.
+
+Built-in parsers and types of their values:
+  - X-Postfix-Queue-ID: string
+  - X-Postfix-Sender: Address
+  - X-Display-Name: string
+  - X-Supplementary-Info: SupplementaryInfo
+  - X-Actual-Recipient: Address
+  - X-Exim-Diagnostic: SMTPDiagnostic
+
+Typed accessors, e.g. DSN.PostfixQueueID, use registered parsers too.
+They return ErrorInvalidExtension when replacing parser returns value of other type.
+*/
+func RegisterExtension(name string, parser ExtensionParser) {
+	extensionParsersMu.Lock()
+	defer extensionParsersMu.Unlock()
+
+	extensionParsers[textproto.CanonicalMIMEHeaderKey(name)] = parser
+}
+
+// LookupExtension returns parser registered for extension field name.
+// The second result is false if there is no such parser.
+func LookupExtension(name string) (ExtensionParser, bool) {
+	extensionParsersMu.RLock()
+	defer extensionParsersMu.RUnlock()
+
+	parser, ok := extensionParsers[textproto.CanonicalMIMEHeaderKey(name)]
+	return parser, ok
+}
+
+// Parse parses value of extension field with registered parser.
+// ErrorFieldNotPresent is returned when field is absent or empty,
+// ErrorUnknownExtension when there is no parser for the field.
+func (e Extensions) Parse(name string) (interface{}, error) {
+	value, err := extensionValue(e, name)
+	if err != nil {
+		return nil, err
+	}
+
+	parser, ok := LookupExtension(name)
+	if !ok {
+		return nil, ErrorUnknownExtension
+	}
+
+	return parser(value)
+}
+
+// extensionValue returns trimmed value of extension field.
+// ErrorFieldNotPresent is returned when field is absent or empty.
+func extensionValue(e Extensions, name string) (string, error) {
+	value := strings.TrimSpace(e.Get(name))
+	if value == "" {
+		return "", ErrorFieldNotPresent
+	}
+	return value, nil
+}
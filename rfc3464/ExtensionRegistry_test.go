@@ -0,0 +1,77 @@
+package rfc3464
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Extensions_Parse(t *testing.T) {
+	RegisterExtension("x-test-count", func(value string) (interface{}, error) {
+		return strconv.Atoi(value)
+	})
+
+	e := make(Extensions)
+	e.Set("X-Test-Count", " 42 ")
+	e.Set("X-Postfix-Queue-ID", "3354017BFA8")
+	e.Set("X-Other", "value")
+
+	type fixture struct {
+		name     string
+		expected interface{}
+		err      error
+	}
+
+	fixtures := []fixture{
+		fixture{name: "X-TEST-COUNT", expected: 42},
+		fixture{name: "x-postfix-queue-id", expected: "3354017BFA8"},
+		fixture{name: "X-Other", err: ErrorUnknownExtension},
+		fixture{name: "X-Missing", err: ErrorFieldNotPresent},
+	}
+
+	for _, f := range fixtures {
+		value, err := e.Parse(f.name)
+
+		assert.Equal(t, f.err, err, "Fixture: %s", f.name)
+		assert.Equal(t, f.expected, value, "Fixture: %s", f.name)
+	}
+}
+
+func Test_Extensions_Override(t *testing.T) {
+	builtin, _ := LookupExtension("X-Display-Name")
+	defer RegisterExtension("X-Display-Name", builtin)
+
+	record := RecipientRecord{Extensions: Extensions{}}
+	record.Extensions.Set("X-Display-Name", "John Doe")
+
+	RegisterExtension("X-Display-Name", func(value string) (interface{}, error) {
+		return strings.ToUpper(value), nil
+	})
+
+	name, err := record.DisplayName()
+	if assert.NoError(t, err) {
+		assert.Equal(t, "JOHN DOE", name)
+	}
+
+	RegisterExtension("X-Display-Name", func(value string) (interface{}, error) {
+		return len(value), nil
+	})
+
+	_, err = record.DisplayName()
+	assert.Equal(t, ErrorInvalidExtension, err)
+}
+
+func Test_LookupExtension(t *testing.T) {
+	for _, name := range []string{
+		"X-Postfix-Queue-ID", "X-Postfix-Sender", "X-Display-Name",
+		"X-Supplementary-Info", "X-Actual-Recipient", "X-Exim-Diagnostic",
+	} {
+		_, ok := LookupExtension(name)
+		assert.True(t, ok, "Extension: %s", name)
+	}
+
+	_, ok := LookupExtension("X-Unknown-Extension")
+	assert.False(t, ok)
+}
@@ -0,0 +1,38 @@
+package rfc3464
+
+import (
+	"net/textproto"
+
+	"github.com/YouDoCom/go-maildsnparsers/internal/fieldblock"
+)
+
+// Field is DSN field as it is written in delivery-status part.
+// Line is 1-based number of its first line in delivery-status part.
+type Field = fieldblock.Field
+
+// Fields is list of fields of DSN or RecipientRecord in original order.
+// Repeated fields are kept as separate entries.
+type Fields = fieldblock.Fields
+
+// repeatedFields returns names of single-instance fields which appear more than once,
+// in order of their first appearance and spelled as in RFC3464
+func repeatedFields(f Fields, names map[string]string) []string {
+	var (
+		result []string
+		seen   = make(map[string]int)
+	)
+
+	for _, field := range f {
+		name, ok := names[textproto.CanonicalMIMEHeaderKey(field.Name)]
+		if !ok {
+			continue
+		}
+
+		seen[name]++
+		if seen[name] == 2 {
+			result = append(result, name)
+		}
+	}
+
+	return result
+}
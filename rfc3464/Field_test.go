@@ -0,0 +1,61 @@
+package rfc3464
+
+import (
+	"net/mail"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Parse_Fields(t *testing.T) {
+	value := `From: Mail Delivery Subsystem <MAILER-DAEMON@example.com>
+Content-Type: multipart/report; report-type=delivery-status; boundary="b"
+
+--b
+Content-Type: message/delivery-status
+
+reporting-mta: dns; mx.example.com
+X-Queue-ID: 1
+X-Queue-ID: 2
+
+Final-Recipient: rfc822; user@example.com
+Action: failed
+Status: 5.1.1
+Action: delayed
+
+--b--
+`
+
+	msg, _ := mail.ReadMessage(strings.NewReader(value))
+	dsn, err := Parse(msg)
+
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, Fields{
+		Field{Name: "reporting-mta", Value: "dns; mx.example.com", Raw: " dns; mx.example.com", Line: 1},
+		Field{Name: "X-Queue-ID", Value: "1", Raw: " 1", Line: 2},
+		Field{Name: "X-Queue-ID", Value: "2", Raw: " 2", Line: 3},
+	}, dsn.Fields)
+	assert.Empty(t, dsn.RepeatedFields())
+	assert.Equal(t, "1\n2", dsn.Extensions.Get("X-Queue-ID"))
+
+	record := dsn.Recipients[0]
+
+	assert.Equal(t, []string{"Final-Recipient", "Action", "Status", "Action"}, fieldNamesOf(record.Fields))
+	assert.Equal(t, 8, record.Fields[3].Line)
+	assert.Equal(t, []string{"Action"}, record.RepeatedFields())
+	assert.Equal(t, ActionFailed, record.Action)
+	assert.Equal(t, 2, record.Fields.Count("action"))
+	assert.Equal(t, []Field{record.Fields[0]}, record.Fields.Lookup("final-recipient"))
+}
+
+func fieldNamesOf(fields Fields) []string {
+	names := make([]string, len(fields))
+	for i, field := range fields {
+		names[i] = field.Name
+	}
+	return names
+}
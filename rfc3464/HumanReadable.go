@@ -0,0 +1,121 @@
+package rfc3464
+
+import (
+	"html"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"regexp"
+	"strings"
+
+	"github.com/YouDoCom/go-maildsnparsers/dsnerrors"
+)
+
+// readHumanReadable reads the first part of multipart/report as UTF-8 text.
+//
+// For multipart/alternative and other multipart containers the text/plain
+// alternative is preferred, text/html is used when there is no plain text.
+// The part is optional, so undecodable content results in empty text.
+// Nested parts are counted and their depth is checked, LimitError is
+// returned when MaxParts or MaxDepth is exceeded.
+func (w *walker) readHumanReadable(hdr textproto.MIMEHeader, body io.Reader, depth int) (string, error) {
+	mediatype, params, err := mime.ParseMediaType(hdr.Get("Content-Type"))
+	if err != nil {
+		// RFC2045 default is text/plain; charset=us-ascii
+		mediatype, params = "text/plain", nil
+	}
+
+	switch {
+	case mediatype == "text/plain" || mediatype == "text/html":
+		r, err := decodeTransferEncoding(hdr, body)
+		if err != nil {
+			return "", nil
+		}
+
+		data, err := ioutil.ReadAll(r)
+		if _, ok := err.(*dsnerrors.LimitError); ok {
+			return "", err
+		}
+		if err != nil {
+			return "", nil
+		}
+
+		text, err := decodeCharset(params["charset"], data, w.charsetReader)
+		if err != nil {
+			return "", nil
+		}
+
+		if mediatype == "text/html" {
+			return htmlToText(text), nil
+		}
+		return strings.TrimSpace(text), nil
+	case strings.HasPrefix(mediatype, "multipart/") && params["boundary"] != "":
+		if depth >= w.maxDepth {
+			return "", w.depthError()
+		}
+
+		r := multipart.NewReader(body, params["boundary"])
+
+		var htmlText string
+
+		for {
+			p, err := r.NextPart()
+			if err != nil {
+				if _, ok := err.(*dsnerrors.LimitError); ok {
+					return "", err
+				}
+				return htmlText, nil
+			}
+
+			if err := w.countPart(); err != nil {
+				return "", err
+			}
+
+			partType, _, _ := mime.ParseMediaType(p.Header.Get("Content-Type"))
+
+			switch {
+			case partType == "text/plain" || partType == "" || strings.HasPrefix(partType, "multipart/"):
+				text, err := w.readHumanReadable(p.Header, p, depth+1)
+				if err != nil || text != "" {
+					return text, err
+				}
+			case partType == "text/html" && htmlText == "":
+				if htmlText, err = w.readHumanReadable(p.Header, p, depth+1); err != nil {
+					return "", err
+				}
+			}
+		}
+	}
+
+	return "", nil
+}
+
+var (
+	htmlInvisibleRe = regexp.MustCompile(`(?is)<(script|style|head)\b.*?</(script|style|head)\s*>|<!--.*?-->`)
+	htmlBreakRe     = regexp.MustCompile(`(?i)<br\s*/?>|</?(p|div|tr|li|h[1-6]|table|ul|ol|blockquote)\b[^>]*>`)
+	htmlTagRe       = regexp.MustCompile(`(?s)<[^>]*>`)
+	spacesRe        = regexp.MustCompile(`[ \t\r\f\v\x{00A0}]+`)
+	blankLinesRe    = regexp.MustCompile(`\n{3,}`)
+)
+
+// htmlToText strips tags from HTML leaving text with line breaks
+// in place of block elements
+func htmlToText(s string) string {
+	s = htmlInvisibleRe.ReplaceAllString(s, "")
+	s = spacesRe.ReplaceAllString(s, " ")
+	s = strings.Replace(s, "\n", " ", -1)
+	s = htmlBreakRe.ReplaceAllString(s, "\n")
+	s = htmlTagRe.ReplaceAllString(s, "")
+	s = html.UnescapeString(s)
+	s = spacesRe.ReplaceAllString(s, " ")
+
+	lines := strings.Split(s, "\n")
+	for i := range lines {
+		lines[i] = strings.TrimSpace(lines[i])
+	}
+	s = strings.Join(lines, "\n")
+
+	return strings.TrimSpace(blankLinesRe.ReplaceAllString(s, "\n\n"))
+}
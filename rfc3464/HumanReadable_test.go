@@ -0,0 +1,137 @@
+package rfc3464
+
+import (
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_readHumanReadable(t *testing.T) {
+	type fixture struct {
+		header   textproto.MIMEHeader
+		body     string
+		expected string
+	}
+
+	fixtures := []fixture{
+		fixture{
+			header:   textproto.MIMEHeader{},
+			body:     "\nThe original message was received.\n",
+			expected: "The original message was received.",
+		},
+		fixture{
+			header: textproto.MIMEHeader{
+				"Content-Type":              []string{"text/plain; charset=windows-1251"},
+				"Content-Transfer-Encoding": []string{"base64"},
+			},
+			body:     "0e7u4fnl7ejlIO3lIOTu8fLg4uvl7e4=",
+			expected: "Сообщение не доставлено",
+		},
+		fixture{
+			header: textproto.MIMEHeader{
+				"Content-Type":              []string{`text/plain; charset="KOI8-R"`},
+				"Content-Transfer-Encoding": []string{"quoted-printable"},
+			},
+			body:     "=EF=DB=C9=C2=CB=C1 =C4=CF=D3=D4=C1=D7=CB=C9",
+			expected: "Ошибка доставки",
+		},
+		fixture{
+			header: textproto.MIMEHeader{
+				"Content-Type": []string{"text/plain; charset=iso-8859-1"},
+			},
+			body:     "Zustellung fehlgeschlagen: M\xfcller",
+			expected: "Zustellung fehlgeschlagen: Müller",
+		},
+		fixture{
+			header: textproto.MIMEHeader{
+				"Content-Type": []string{"text/html; charset=utf-8"},
+			},
+			body: `<html><head><title>Bounce</title><style>p {color: red}</style></head>
+<body><p>Delivery   to <b>user@example.com</b> failed.</p><script>alert(1)</script>
+<div>Reason:&nbsp;mailbox&#32;full &amp; disabled</div><br/>Bye</body></html>`,
+			expected: "Delivery to user@example.com failed.\n\nReason: mailbox full & disabled\n\nBye",
+		},
+		fixture{
+			header: textproto.MIMEHeader{
+				"Content-Type": []string{`multipart/alternative; boundary="ALT"`},
+			},
+			body: `--ALT
+Content-Type: text/html
+
+<p>HTML version</p>
+--ALT
+Content-Type: text/plain
+
+Plain version
+--ALT--
+`,
+			expected: "Plain version",
+		},
+		fixture{
+			header: textproto.MIMEHeader{
+				"Content-Type": []string{`multipart/alternative; boundary="ALT"`},
+			},
+			body: `--ALT
+Content-Type: text/html
+
+<p>HTML only</p>
+--ALT--
+`,
+			expected: "HTML only",
+		},
+		fixture{
+			header: textproto.MIMEHeader{
+				"Content-Type": []string{"image/png"},
+			},
+			body:     "PNG",
+			expected: "",
+		},
+		fixture{
+			header: textproto.MIMEHeader{
+				"Content-Type": []string{"text/plain; charset=x-unknown"},
+			},
+			body:     "text",
+			expected: "",
+		},
+	}
+
+	for _, f := range fixtures {
+		got, err := newWalker(ParseOptions{}).readHumanReadable(f.header, strings.NewReader(f.body), 1)
+
+		assert.NoError(t, err, "Fixture: %#v", f.header)
+		assert.Equal(t, f.expected, got, "Fixture: %#v", f.header)
+	}
+}
+
+func Test_Parse_HumanReadable(t *testing.T) {
+	value := `From: MAILER-DAEMON@example.com
+MIME-Version: 1.0
+Content-Type: multipart/report; report-type=delivery-status; boundary="XXX"
+
+--XXX
+Content-Type: text/plain; charset=windows-1251
+Content-Transfer-Encoding: base64
+
+0e7u4fnl7ejlIO3lIOTu8fLg4uvl7e4=
+--XXX
+Content-Type: message/delivery-status
+
+Reporting-MTA: dns; mx.example.com
+
+Final-Recipient: rfc822; user@example.com
+Action: failed
+Status: 5.1.1
+
+--XXX--
+`
+
+	msg, _ := mail.ReadMessage(strings.NewReader(value))
+	dsn, err := Parse(msg)
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, "Сообщение не доставлено", dsn.HumanReadable)
+	}
+}
@@ -0,0 +1,204 @@
+package rfc3464
+
+import (
+	"bytes"
+	"io"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/YouDoCom/go-maildsnparsers/dsnerrors"
+	"github.com/YouDoCom/go-maildsnparsers/internal/message"
+)
+
+// maxLineLength is length of line after which field is folded
+const maxLineLength = 78
+
+/*
+Marshal serializes DSN as body of message/delivery-status part.
+
+Per-message fields are written first, followed by blank line separated
+per-recipient fields, both in RFC3464 order. Empty fields are omitted,
+extension fields are written after standard ones, sorted by name.
+Values containing line breaks are written as repeated fields.
+
+Original-Recipient value is written as RFC3461 xtext: bytes which are
+not xchar are encoded, valid hexchars are kept, so parsed value is written
+back unchanged. Addresses of "utf-8" type are encoded as RFC6533
+utf-8-addr-xtext unless DSN is Global.
+
+Per-message block is required, so DSN without per-message fields
+is not written.
+
+Comments removed from structured fields by parser are written back.
+Long lines are folded at single spaces only, so parsing the output
+gives back equal DSN. Fields lists are not written.
+*/
+func Marshal(dsn *DSN) ([]byte, error) {
+	var b bytes.Buffer
+
+	if _, err := dsn.WriteTo(&b); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// WriteTo writes DSN as body of message/delivery-status part. See Marshal.
+func (dsn *DSN) WriteTo(w io.Writer) (int64, error) {
+	var b bytes.Buffer
+
+	writeField(&b, "Original-Envelope-Id", dsn.OriginalEnvelopeID)
+	writeField(&b, "Reporting-MTA", withComments(dsn.ReportingMTA.String(), dsn.Comments.Get("Reporting-MTA")))
+	writeField(&b, "DSN-Gateway", withComments(dsn.DsnGateway.String(), dsn.Comments.Get("DSN-Gateway")))
+	writeField(&b, "Received-From-MTA", withComments(dsn.ReceivedFromMTA.String(), dsn.Comments.Get("Received-From-MTA")))
+	writeField(&b, "Arrival-Date", withComments(dsn.ArrivalDate, dsn.Comments.Get("Arrival-Date")))
+	writeExtensions(&b, dsn.Extensions)
+
+	if b.Len() == 0 {
+		return 0, &dsnerrors.MissingFieldError{Field: "Reporting-MTA"}
+	}
+
+	for i := range dsn.Recipients {
+		b.WriteString("\r\n")
+		dsn.Recipients[i].write(&b, dsn.Global)
+	}
+
+	n, err := w.Write(b.Bytes())
+	return int64(n), err
+}
+
+func (record *RecipientRecord) write(b *bytes.Buffer, global bool) {
+	writeField(b, "Original-Recipient", addressWithComments(encodeOriginalAddressField(record.OriginalRecipient, global), record.Comments.Get("Original-Recipient")))
+	writeField(b, "Final-Recipient", addressWithComments(encodeUTF8AddressField(record.FinalRecipient, global), record.Comments.Get("Final-Recipient")))
+	writeField(b, "Action", withComments(string(record.Action), record.Comments.Get("Action")))
+	writeField(b, "Status", withComments(record.Status, record.Comments.Get("Status")))
+	writeField(b, "Remote-MTA", withComments(record.RemoteMTA.String(), record.Comments.Get("Remote-MTA")))
+	writeField(b, "Diagnostic-Code", record.DiagnosticCode.String())
+	writeField(b, "Last-Attempt-Date", withComments(record.LastAttemptDate, record.Comments.Get("Last-Attempt-Date")))
+	writeField(b, "Final-Log-ID", record.FinalLogID)
+	writeField(b, "Will-Retry-Until", withComments(record.WillRetryUntil, record.Comments.Get("Will-Retry-Until")))
+	writeExtensions(b, record.Extensions)
+}
+
+// withComments appends comments removed by parser to value of structured field.
+// Comments of field without value are dropped.
+func withComments(value string, comments []string) string {
+	if strings.TrimSpace(value) == "" {
+		return value
+	}
+	for _, comment := range comments {
+		value += " (" + comment + ")"
+	}
+	return value
+}
+
+// addressWithComments writes comments removed by parser after address-type,
+// since generic-address is *text. Comments of field without type are dropped.
+func addressWithComments(field TypeValueField, comments []string) string {
+	if field.Value != "" {
+		field.Type = withComments(field.Type, comments)
+	}
+	return field.String()
+}
+
+// encodeOriginalAddressField encodes value of Original-Recipient field,
+// which is xtext for all but "utf-8" type
+func encodeOriginalAddressField(field TypeValueField, global bool) TypeValueField {
+	if strings.EqualFold(field.Type, AddressTypeUTF8) {
+		return encodeUTF8AddressField(field, global)
+	}
+
+	field.Value = escapeXtext(field.Value)
+	return field
+}
+
+// encodeUTF8AddressField encodes value of "utf-8" typed field,
+// raw UTF-8 is kept for RFC6533 global DSN
+func encodeUTF8AddressField(field TypeValueField, global bool) TypeValueField {
+	if !strings.EqualFold(field.Type, AddressTypeUTF8) {
+		return field
+	}
+
+	if global && utf8.ValidString(field.Value) && !strings.Contains(field.Value, `\`) {
+		return field
+	}
+
+	field.Value = EncodeUTF8Address(field.Value)
+	return field
+}
+
+func writeExtensions(b *bytes.Buffer, extensions Extensions) {
+	keys := make([]string, 0, len(extensions))
+	for k := range extensions {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if message.IsFieldName(k) {
+			writeField(b, k, extensions[k])
+		}
+	}
+}
+
+// writeField writes field folding long lines. Empty field is not written.
+// Every line of multi-line value is written as separate field.
+func writeField(b *bytes.Buffer, name, value string) {
+	if strings.TrimSpace(value) == "" {
+		return
+	}
+
+	for _, line := range strings.Split(value, "\n") {
+		line = strings.TrimSpace(strings.Replace(line, "\r", " ", -1))
+		if line == "" {
+			continue
+		}
+
+		b.WriteString(foldField(name + ": " + line))
+		b.WriteString("\r\n")
+	}
+}
+
+// foldField folds line longer than maxLineLength. Line is broken at
+// single spaces only, because parser replaces white space around line
+// break with one space. Space after field name is never broken,
+// so the first line always has some value.
+func foldField(line string) string {
+	if len(line) <= maxLineLength {
+		return line
+	}
+
+	var (
+		b     strings.Builder
+		start int // start of current output line
+		last  = -1
+	)
+
+	for i := strings.Index(line, ": ") + 2; i < len(line)-1; i++ {
+		if line[i] != ' ' || isSpace(line[i-1]) || isSpace(line[i+1]) {
+			continue
+		}
+
+		if i-start > maxLineLength && last > start {
+			b.WriteString(line[start:last])
+			b.WriteString("\r\n")
+			start = last
+		}
+		last = i
+	}
+
+	if len(line)-start > maxLineLength && last > start {
+		b.WriteString(line[start:last])
+		b.WriteString("\r\n")
+		start = last
+	}
+
+	b.WriteString(line[start:])
+
+	return b.String()
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t'
+}
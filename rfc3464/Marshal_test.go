@@ -0,0 +1,257 @@
+package rfc3464
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Marshal(t *testing.T) {
+	dsn := &DSN{
+		ReportingMTA: TypeValueField{Type: "dns", Value: "mx.example.com"},
+		ArrivalDate:  "Thu, 7 Jul 1994 17:15:49 -0400",
+		Extensions:   Extensions{"X-Queue-Id": "1"},
+		Recipients: []RecipientRecord{
+			RecipientRecord{
+				OriginalRecipient: TypeValueField{Type: "rfc822", Value: "user@example.com"},
+				FinalRecipient:    TypeValueField{Type: "rfc822", Value: "user@example.com"},
+				Action:            RecipientAction("failed"),
+				Status:            "5.1.1",
+				Extensions:        Extensions{"X-B": "b", "X-A": "a"},
+			},
+			RecipientRecord{
+				FinalRecipient: TypeValueField{Type: "rfc822", Value: "other@example.com"},
+				Action:         RecipientAction("delayed"),
+				Status:         "4.2.2",
+			},
+		},
+	}
+
+	data, err := Marshal(dsn)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Reporting-MTA: dns; mx.example.com\r\n"+
+		"Arrival-Date: Thu, 7 Jul 1994 17:15:49 -0400\r\n"+
+		"X-Queue-Id: 1\r\n"+
+		"\r\n"+
+		"Original-Recipient: rfc822; user@example.com\r\n"+
+		"Final-Recipient: rfc822; user@example.com\r\n"+
+		"Action: failed\r\n"+
+		"Status: 5.1.1\r\n"+
+		"X-A: a\r\n"+
+		"X-B: b\r\n"+
+		"\r\n"+
+		"Final-Recipient: rfc822; other@example.com\r\n"+
+		"Action: delayed\r\n"+
+		"Status: 4.2.2\r\n", string(data))
+
+	var b bytes.Buffer
+	n, err := dsn.WriteTo(&b)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(data)), n)
+	assert.Equal(t, data, b.Bytes())
+}
+
+func Test_Marshal_RoundTrip(t *testing.T) {
+	longDiagnostic := "550-5.1.1 The email account that you tried to reach does not exist. " +
+		"Please try double-checking the recipient's email address for typos or  unnecessary " +
+		"spaces. Learn more at https://support.example.com/mail/?p=NoSuchUser " +
+		strings.Repeat("x", 100) + " end"
+
+	type fixture struct {
+		name string
+		dsn  *DSN
+	}
+
+	fixtures := []fixture{
+		fixture{
+			name: "all fields",
+			dsn: &DSN{
+				OriginalEnvelopeID: "envelope-1",
+				ReportingMTA:       TypeValueField{Type: "dns", Value: "mx.example.com"},
+				DsnGateway:         TypeValueField{Type: "dns", Value: "gw.example.com"},
+				ReceivedFromMTA:    TypeValueField{Type: "dns", Value: "client.example.com"},
+				ArrivalDate:        "Thu, 7 Jul 1994 17:15:49 -0400",
+				Extensions:         Extensions{"X-Postfix-Queue-Id": "3354017BFA8", "X-Multi": "one\ntwo"},
+				Comments:           Comments{"Received-From-Mta": []string{"192.0.2.1"}},
+				Recipients: []RecipientRecord{
+					RecipientRecord{
+						OriginalRecipient: TypeValueField{Type: "rfc822", Value: "user+2Btag@example.com"},
+						FinalRecipient:    TypeValueField{Type: "rfc822", Value: "user@example.com"},
+						Action:            RecipientAction("failed"),
+						Status:            "5.1.1",
+						RemoteMTA:         TypeValueField{Type: "dns", Value: "mx.remote.example"},
+						DiagnosticCode:    TypeValueField{Type: "smtp", Value: longDiagnostic},
+						LastAttemptDate:   "Thu, 7 Jul 1994 17:15:49 -0400",
+						FinalLogID:        "log-1",
+						WillRetryUntil:    "Fri, 8 Jul 1994 17:15:49 -0400",
+						Extensions:        Extensions{"X-Note": "note"},
+						Comments:          Comments{"Status": []string{"bad mailbox", "nested (comment)"}, "Final-Recipient": []string{"smtp"}},
+					},
+					RecipientRecord{
+						OriginalRecipient: TypeValueField{Type: "x400", Value: "/G+3DJohn/S+3DDoe(Sales)/O+3DOrg/"},
+						FinalRecipient:    TypeValueField{Type: "utf-8", Value: "я@пример.рф"},
+						Action:            RecipientAction("delayed"),
+						Status:            "4.4.1",
+						Extensions:        Extensions{},
+					},
+				},
+			},
+		},
+		fixture{
+			name: "global",
+			dsn: &DSN{
+				ReportingMTA: TypeValueField{Type: "dns", Value: "почта.example"},
+				Extensions:   Extensions{},
+				Global:       true,
+				Recipients: []RecipientRecord{
+					RecipientRecord{
+						OriginalRecipient: TypeValueField{Type: "utf-8", Value: "я@пример.рф"},
+						FinalRecipient:    TypeValueField{Type: "utf-8", Value: "я@пример.рф"},
+						Action:            RecipientAction("delivered"),
+						Status:            "2.0.0",
+						Extensions:        Extensions{},
+					},
+				},
+			},
+		},
+	}
+
+	for _, f := range fixtures {
+		data, err := Marshal(f.dsn)
+		if !assert.NoError(t, err, "Fixture: %s", f.name) {
+			continue
+		}
+
+		for _, line := range strings.Split(string(data), "\r\n") {
+			assert.True(t, len(line) <= maxLineLength || !strings.Contains(line[1:], " "), "Line too long: %q", line)
+		}
+
+		got, err := (&walker{}).parseReport(bytes.NewReader(data))
+		if !assert.NoError(t, err, "Fixture: %s", f.name) {
+			continue
+		}
+
+		got.Fields = nil
+		got.Global = f.dsn.Global
+		for i := range got.Recipients {
+			got.Recipients[i].Fields = nil
+		}
+
+		assert.Equal(t, f.dsn, got, "Fixture: %s", f.name)
+	}
+}
+
+func Test_Marshal_Encoding(t *testing.T) {
+	dsn := &DSN{
+		ReportingMTA: TypeValueField{Type: "dns", Value: "mx.example.com"},
+		Recipients: []RecipientRecord{
+			RecipientRecord{
+				OriginalRecipient: TypeValueField{Type: "rfc822", Value: EncodeXtext("odd user@example.com")},
+				FinalRecipient:    TypeValueField{Type: "utf-8", Value: "я@example.com"},
+				Action:            RecipientAction("failed"),
+				Status:            "5.1.1",
+			},
+		},
+	}
+
+	data, _ := Marshal(dsn)
+
+	assert.Contains(t, string(data), "Original-Recipient: rfc822; odd+20user@example.com\r\n")
+	assert.Contains(t, string(data), `Final-Recipient: utf-8; \x{44F}@example.com`+"\r\n")
+}
+
+func Test_Marshal_OriginalRecipient(t *testing.T) {
+	type fixture struct {
+		value   string
+		decoded string
+		encoded string
+	}
+
+	fixtures := []fixture{
+		fixture{value: EncodeXtext("john+2024@example.com"), decoded: "john+2024@example.com", encoded: "john+2B2024@example.com"},
+		fixture{value: EncodeXtext("a=b@example.com"), decoded: "a=b@example.com", encoded: "a+3Db@example.com"},
+		fixture{value: EncodeXtext("a b@example.com"), decoded: "a b@example.com", encoded: "a+20b@example.com"},
+		fixture{value: "a b=c@example.com", decoded: "a b=c@example.com", encoded: "a+20b+3Dc@example.com"},
+		fixture{value: "a+b@example.com", decoded: "a+b@example.com", encoded: "a+2Bb@example.com"},
+	}
+
+	for _, f := range fixtures {
+		dsn := &DSN{
+			ReportingMTA: TypeValueField{Type: "dns", Value: "mx.example.com"},
+			Recipients: []RecipientRecord{
+				RecipientRecord{
+					OriginalRecipient: TypeValueField{Type: "rfc822", Value: f.value},
+					FinalRecipient:    TypeValueField{Type: "rfc822", Value: "user@example.com"},
+					Action:            ActionFailed,
+					Status:            "5.1.1",
+				},
+			},
+		}
+
+		data, err := Marshal(dsn)
+		if !assert.NoError(t, err, "Fixture: %q", f.decoded) {
+			continue
+		}
+		assert.Contains(t, string(data), "Original-Recipient: rfc822; "+f.encoded+"\r\n", "Fixture: %q", f.decoded)
+
+		got, err := (&walker{}).parseReport(bytes.NewReader(data))
+		if !assert.NoError(t, err, "Fixture: %q", f.decoded) {
+			continue
+		}
+
+		record := got.Recipients[0]
+		assert.Equal(t, f.encoded, record.OriginalRecipient.Value, "Fixture: %q", f.decoded)
+
+		decoded, err := DecodeXtext(record.OriginalRecipient.Value)
+		assert.NoError(t, err, "Fixture: %q", f.decoded)
+		assert.Equal(t, f.decoded, decoded, "Fixture: %q", f.decoded)
+
+		// written unchanged, so marshaling parsed DSN gives the same output
+		again, _ := Marshal(got)
+		assert.Equal(t, string(data), string(again), "Fixture: %q", f.decoded)
+	}
+
+	record := RecipientRecord{OriginalRecipient: TypeValueField{Type: "rfc822", Value: EncodeXtext("john+2024@example.com")}}
+	addr, err := record.OriginalAddress()
+	if assert.NoError(t, err) {
+		assert.Equal(t, "john+2024@example.com", addr.Address)
+	}
+}
+
+func Test_Marshal_NoPerMessageFields(t *testing.T) {
+	dsn := &DSN{
+		Recipients: []RecipientRecord{
+			RecipientRecord{
+				FinalRecipient: TypeValueField{Type: "rfc822", Value: "user@example.com"},
+				Action:         ActionFailed,
+				Status:         "5.1.1",
+			},
+		},
+	}
+
+	data, err := Marshal(dsn)
+	assert.Nil(t, data)
+	assert.True(t, errors.Is(err, ErrorRequiredFieldMissing), "Error: %v", err)
+}
+
+func Test_foldField(t *testing.T) {
+	line := "Diagnostic-Code: smtp; " + strings.Repeat("word ", 30) + "end"
+
+	folded := foldField(line)
+
+	for _, l := range strings.Split(folded, "\r\n") {
+		assert.True(t, len(l) <= maxLineLength, "Line too long: %q", l)
+	}
+	assert.Equal(t, line, strings.Replace(folded, "\r\n", "", -1))
+
+	line = "X-Long: " + strings.Repeat("x", 100)
+	assert.Equal(t, line, foldField(line))
+
+	line = "X-Spaces: " + strings.Repeat("a  ", 40)
+	assert.Equal(t, line, foldField(line))
+}
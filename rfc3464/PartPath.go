@@ -0,0 +1,39 @@
+package rfc3464
+
+import (
+	"strconv"
+	"strings"
+)
+
+/*
+PartPath represents location of MIME entity in message tree.
+
+Parts are numbered as in IMAP BODY[section] specifier: each number is
+1-based index of body part within its multipart parent, parts of
+encapsulated message/rfc822 continue numbering of the message part.
+Empty path refers to the message itself.
+
+Example:
+    multipart/mixed              ""
+        text/plain               "1"
+        message/rfc822           "2"
+            multipart/report     "2"
+                text/plain       "2.1"
+*/
+type PartPath []int
+
+// String returns dot separated representation of path
+func (p PartPath) String() string {
+	s := make([]string, len(p))
+	for i, n := range p {
+		s[i] = strconv.Itoa(n)
+	}
+	return strings.Join(s, ".")
+}
+
+// child returns path of n-th child part
+func (p PartPath) child(n int) PartPath {
+	c := make(PartPath, len(p), len(p)+1)
+	copy(c, p)
+	return append(c, n)
+}
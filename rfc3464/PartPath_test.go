@@ -0,0 +1,20 @@
+package rfc3464
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PartPath_String(t *testing.T) {
+	assert.Equal(t, "", PartPath(nil).String())
+	assert.Equal(t, "3", PartPath{3}.String())
+	assert.Equal(t, "1.2.3", PartPath{1, 2, 3}.String())
+
+	p := PartPath{1}
+	c1 := p.child(1)
+	c2 := p.child(2)
+	assert.Equal(t, "1.1", c1.String())
+	assert.Equal(t, "1.2", c2.String())
+	assert.Equal(t, "1", p.String())
+}
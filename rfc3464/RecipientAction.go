@@ -6,9 +6,70 @@ import (
 
 /*
 RecipientAction represents type for Action field of Recipient
+
+	action-field = "Action" ":" action-value
+	action-value =
+		"failed" / "delayed" / "delivered" / "relayed" / "expanded"
 */
 type RecipientAction string
 
+// Action values defined by RFC3464
+const (
+	ActionFailed    RecipientAction = "failed"
+	ActionDelayed   RecipientAction = "delayed"
+	ActionDelivered RecipientAction = "delivered"
+	ActionRelayed   RecipientAction = "relayed"
+	ActionExpanded  RecipientAction = "expanded"
+)
+
+// ParseRecipientAction parses action-value ignoring case, comments and
+// trailing punctuation, e.g. "Failed (permanent error)" gives ActionFailed.
+//
+// For values not defined by RFC3464 value with white space trimmed
+// is returned with ErrorInvalidAction. ErrorFieldNotPresent is returned
+// for empty value.
+func ParseRecipientAction(value string) (RecipientAction, error) {
+	action := RecipientAction(value)
+
+	switch normalized := RecipientAction(action.normalize()); normalized {
+	case ActionFailed, ActionDelayed, ActionDelivered, ActionRelayed, ActionExpanded:
+		return normalized, nil
+	case "":
+		return "", ErrorFieldNotPresent
+	}
+
+	return RecipientAction(strings.TrimSpace(value)), ErrorInvalidAction
+}
+
+// IsValid checks that action is one of defined by RFC3464
+func (a RecipientAction) IsValid() bool {
+	_, err := ParseRecipientAction(string(a))
+	return err == nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// Valid action is marshaled in canonical form, e.g. "failed" for "Failed.",
+// unknown values are marshaled with white space trimmed.
+func (a RecipientAction) MarshalText() ([]byte, error) {
+	action, _ := ParseRecipientAction(string(a))
+	return []byte(action), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// Valid action is stored in canonical form. Unknown values are kept
+// with white space trimmed, since vendors use their own actions.
+func (a *RecipientAction) UnmarshalText(text []byte) error {
+	*a, _ = ParseRecipientAction(string(text))
+	return nil
+}
+
+// normalize returns lower-cased action-value without comments,
+// white space and trailing punctuation, e.g. "failed" for "Failed. (permanent error)"
+func (a RecipientAction) normalize() string {
+	value := strings.ToLower(stripComments(string(a)))
+	return strings.TrimSpace(strings.TrimRight(value, ".,;:!"))
+}
+
 /*
 IsFailed indicates that the message could not be delivered to the
 recipient.  The Reporting MTA has abandoned any attempts
@@ -16,7 +77,7 @@ to deliver the message to this recipient.  No further
 notifications should be expected.
 */
 func (a RecipientAction) IsFailed() bool {
-	return strings.ToLower(string(a)) == "failed"
+	return RecipientAction(a.normalize()) == ActionFailed
 }
 
 /*
@@ -28,7 +89,7 @@ successfully delivered, or if delivery attempts are later
 abandoned.
 */
 func (a RecipientAction) IsDelayed() bool {
-	return strings.ToLower(string(a)) == "delayed"
+	return RecipientAction(a.normalize()) == ActionDelayed
 }
 
 /*
@@ -40,7 +101,7 @@ terminal state and no further DSN for this recipient
 should be expected.
 */
 func (a RecipientAction) IsDelivered() bool {
-	return strings.ToLower(string(a)) == "delivered"
+	return RecipientAction(a.normalize()) == ActionDelivered
 }
 
 /*
@@ -52,7 +113,7 @@ requested notification of successful delivery for this
 recipient.
 */
 func (a RecipientAction) IsRelayed() bool {
-	return strings.ToLower(string(a)) == "relayed"
+	return RecipientAction(a.normalize()) == ActionRelayed
 }
 
 /*
@@ -65,5 +126,5 @@ that "expanded" is not a terminal state.  Further
 "failed" and/or "delayed" notifications may be provided.
 */
 func (a RecipientAction) IsExpanded() bool {
-	return strings.ToLower(string(a)) == "expanded"
+	return RecipientAction(a.normalize()) == ActionExpanded
 }
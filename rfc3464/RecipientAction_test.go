@@ -1,7 +1,11 @@
 package rfc3464
 
-import "testing"
-import "github.com/stretchr/testify/assert"
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
 
 type TestRecipientActionFixture struct {
 	value    string
@@ -18,6 +22,22 @@ func Test_RecipientAction_IsFailed(t *testing.T) {
 			value:    "Failed",
 			expected: true,
 		},
+		TestRecipientActionFixture{
+			value:    "failed (permanent error)",
+			expected: true,
+		},
+		TestRecipientActionFixture{
+			value:    " FAILED. ",
+			expected: true,
+		},
+		TestRecipientActionFixture{
+			value:    "failed;",
+			expected: true,
+		},
+		TestRecipientActionFixture{
+			value:    "failed permanently",
+			expected: false,
+		},
 		TestRecipientActionFixture{
 			value:    "",
 			expected: false,
@@ -127,3 +147,62 @@ func Test_RecipientAction_IsExpanded(t *testing.T) {
 		assert.Equal(t, f.expected, got)
 	}
 }
+
+func Test_ParseRecipientAction(t *testing.T) {
+	type fixture struct {
+		value    string
+		expected RecipientAction
+		err      error
+	}
+
+	fixtures := []fixture{
+		fixture{value: "failed", expected: ActionFailed},
+		fixture{value: "Delayed", expected: ActionDelayed},
+		fixture{value: " DELIVERED. ", expected: ActionDelivered},
+		fixture{value: "relayed (to legacy system)", expected: ActionRelayed},
+		fixture{value: "expanded;", expected: ActionExpanded},
+		fixture{value: " bounced ", expected: RecipientAction("bounced"), err: ErrorInvalidAction},
+		fixture{value: "fialed", expected: RecipientAction("fialed"), err: ErrorInvalidAction},
+		fixture{value: " (no action) ", expected: "", err: ErrorFieldNotPresent},
+	}
+
+	for _, f := range fixtures {
+		action, err := ParseRecipientAction(f.value)
+
+		assert.Equal(t, f.err, err, "Fixture: %q", f.value)
+		assert.Equal(t, f.expected, action, "Fixture: %q", f.value)
+		assert.Equal(t, f.err == nil, RecipientAction(f.value).IsValid(), "Fixture: %q", f.value)
+	}
+}
+
+func Test_RecipientAction_Text(t *testing.T) {
+	type record struct {
+		Action RecipientAction `json:"action"`
+	}
+
+	type fixture struct {
+		value    RecipientAction
+		expected string
+	}
+
+	fixtures := []fixture{
+		fixture{value: ActionFailed, expected: `{"action":"failed"}`},
+		fixture{value: RecipientAction("Failed (permanent error)"), expected: `{"action":"failed"}`},
+		fixture{value: RecipientAction(" bounced "), expected: `{"action":"bounced"}`},
+		fixture{value: "", expected: `{"action":""}`},
+	}
+
+	for _, f := range fixtures {
+		data, err := json.Marshal(record{Action: f.value})
+
+		assert.NoError(t, err, "Fixture: %q", f.value)
+		assert.Equal(t, f.expected, string(data), "Fixture: %q", f.value)
+	}
+
+	var r record
+	assert.NoError(t, json.Unmarshal([]byte(`{"action":"DELAYED."}`), &r))
+	assert.Equal(t, ActionDelayed, r.Action)
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"action":"bounced"}`), &r))
+	assert.Equal(t, RecipientAction("bounced"), r.Action)
+}
@@ -3,6 +3,7 @@ package rfc3464
 import (
 	"net/textproto"
 	"strings"
+	"time"
 )
 
 /*
@@ -26,6 +27,12 @@ RecipientRecord represents per-recipient DSN record
 			[ final-log-id-field CRLF ]
 			[ will-retry-until-field CRLF ]
 			*( extension-field CRLF )
+
+Original-Recipient and Final-Recipient values are generic-address as
+written in report, except addresses of "utf-8" type, which are decoded
+by parser. Original-Recipient value of other types is RFC3461 xtext,
+Marshal encodes bytes which are not valid in it. Use OriginalAddress
+and FinalAddress to get decoded addresses.
 */
 type RecipientRecord struct {
 	/*
@@ -368,10 +375,18 @@ type RecipientRecord struct {
 		"X-", (e.g., "X-Foomail-Log-ID").
 	*/
 	Extensions Extensions
+
+	// Fields lists fields as written in record, in original order.
+	// It is empty if record was not parsed from delivery-status part.
+	Fields Fields
+
+	// Comments removed from structured fields, nil if there are no comments
+	Comments Comments
 }
 
 func (record *RecipientRecord) fillFromHeader(hdr textproto.MIMEHeader) {
 	record.Extensions = make(Extensions)
+	comments := make(Comments)
 
 	var (
 		keyOriginalRecipient = textproto.CanonicalMIMEHeaderKey("Original-Recipient")
@@ -386,30 +401,102 @@ func (record *RecipientRecord) fillFromHeader(hdr textproto.MIMEHeader) {
 	)
 
 	for k, v := range hdr {
-		val := strings.Join(v, "\n")
+		val := v[0]
 
 		switch k {
 		case keyOriginalRecipient:
-			record.OriginalRecipient = ParseTypeValueField(val)
+			record.OriginalRecipient = decodeUTF8AddressField(comments.stripAddress(k, val))
 		case keyFinalRecipient:
-			record.FinalRecipient = ParseTypeValueField(val)
+			record.FinalRecipient = decodeUTF8AddressField(comments.stripAddress(k, val))
 		case keyAction:
-			record.Action = RecipientAction(val)
+			record.Action = RecipientAction(comments.strip(k, val))
 		case keyStatus:
-			record.Status = val
+			record.Status = comments.strip(k, val)
 		case keyRemoteMTA:
-			record.RemoteMTA = ParseTypeValueField(val)
+			record.RemoteMTA = ParseTypeValueField(comments.strip(k, val))
 		case keyDiagnosticCode:
 			record.DiagnosticCode = ParseTypeValueField(val)
 		case keyLastAttemptDate:
-			record.LastAttemptDate = val
+			record.LastAttemptDate = comments.strip(k, val)
 		case keyFinalLogID:
 			record.FinalLogID = val
 		case keyWillRetryUntil:
-			record.WillRetryUntil = val
+			record.WillRetryUntil = comments.strip(k, val)
 
 		default:
-			record.Extensions.Set(k, val)
+			record.Extensions.Set(k, strings.Join(v, "\n"))
 		}
 	}
+
+	if len(comments) > 0 {
+		record.Comments = comments
+	}
+}
+
+func (record *RecipientRecord) fillFromFields(fields Fields) {
+	record.Fields = fields
+	record.fillFromHeader(fields.Header())
+}
+
+// RepeatedFields returns names of single-instance fields,
+// which appear in record more than once. Typed fields hold the first occurrence.
+func (record *RecipientRecord) RepeatedFields() []string {
+	return repeatedFields(record.Fields, perRecipientFieldNames)
+}
+
+// EnhancedStatus parses Status field as RFC3463 enhanced status code.
+// Comment removed from Status field by parser is returned in Comment.
+func (record *RecipientRecord) EnhancedStatus() (EnhancedStatus, error) {
+	status, err := ParseEnhancedStatus(record.Status)
+	if err == nil && status.Comment == "" {
+		status.Comment = strings.Join(record.Comments.Get("Status"), " ")
+	}
+	return status, err
+}
+
+// SMTPDiagnostic parses Diagnostic-Code field as SMTP reply.
+// Value is parsed regardless of diagnostic-type, since many MTAs
+// put SMTP replies into their own types (e.g. "X-Postfix").
+func (record *RecipientRecord) SMTPDiagnostic() (SMTPDiagnostic, error) {
+	return ParseSMTPDiagnostic(record.DiagnosticCode.Value)
+}
+
+// LastAttemptTime parses Last-Attempt-Date field.
+// ErrorFieldNotPresent is returned when field is empty.
+func (record *RecipientRecord) LastAttemptTime() (time.Time, error) {
+	return parseDateField(record.LastAttemptDate)
+}
+
+// WillRetryUntilTime parses Will-Retry-Until field.
+// ErrorFieldNotPresent is returned when field is empty.
+func (record *RecipientRecord) WillRetryUntilTime() (time.Time, error) {
+	return parseDateField(record.WillRetryUntil)
+}
+
+// OriginalAddress parses xtext encoded Original-Recipient field.
+// Address of "utf-8" type is decoded by parser already, so it is not decoded again.
+func (record *RecipientRecord) OriginalAddress() (Address, error) {
+	return parseAddress(record.OriginalRecipient, true, true)
+}
+
+// FinalAddress parses Final-Recipient field.
+// Address of "utf-8" type is decoded by parser already, so it is not decoded again.
+func (record *RecipientRecord) FinalAddress() (Address, error) {
+	return parseAddress(record.FinalRecipient, false, true)
+}
+
+// Recipient returns canonical recipient address of the record.
+// Final-Recipient is used if it is valid, otherwise Original-Recipient.
+func (record *RecipientRecord) Recipient() (Address, error) {
+	final, err := record.FinalAddress()
+	if err == nil {
+		return final, nil
+	}
+
+	original, originalErr := record.OriginalAddress()
+	if originalErr == nil || err == ErrorFieldNotPresent {
+		return original, originalErr
+	}
+
+	return final, err
 }
@@ -0,0 +1,11 @@
+package rfc3464
+
+import (
+	"github.com/YouDoCom/go-maildsnparsers/internal/fieldblock"
+)
+
+// RecordError describes malformed block of fields in delivery-status part
+// which was skipped in ContinueOnError mode. Block 0 contains per-message
+// fields, following blocks are per-recipient. Line is 1-based number of
+// malformed line in delivery-status part, Raw is its text.
+type RecordError = fieldblock.RecordError
@@ -0,0 +1,155 @@
+package rfc3464
+
+import (
+	"errors"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"github.com/YouDoCom/go-maildsnparsers/dsnerrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseWithOptions_ContinueOnError(t *testing.T) {
+	value := `From: Mail Delivery Subsystem <MAILER-DAEMON@example.com>
+Content-Type: multipart/report; report-type=delivery-status; boundary="b"
+
+--b
+Content-Type: message/delivery-status
+
+Reporting-MTA: dns; mx.example.com
+
+Final-Recipient: rfc822; first@example.com
+Action: failed
+Status: 5.1.1
+
+Final-Recipient: rfc822; broken@example.com
+Action failed
+Status: 5.1.1
+
+Final-Recipient: rfc822; last@example.com
+Action: failed
+Status: 5.2.2
+Diagnostic-Code: smtp; 552 mailbox
+  full
+
+--b--
+`
+
+	msg, _ := mail.ReadMessage(strings.NewReader(value))
+	dsn, err := ParseWithOptions(msg, ParseOptions{ContinueOnError: true})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "mx.example.com", dsn.ReportingMTA.Value)
+
+	if assert.Len(t, dsn.Recipients, 2) {
+		assert.Equal(t, "first@example.com", dsn.Recipients[0].FinalRecipient.Value)
+		assert.Equal(t, "last@example.com", dsn.Recipients[1].FinalRecipient.Value)
+		assert.Equal(t, "552 mailbox full", dsn.Recipients[1].DiagnosticCode.Value)
+	}
+
+	assert.Equal(t, []*RecordError{
+		&RecordError{Block: 2, Line: 8, Raw: "Action failed", Err: ErrorMalformedField},
+	}, dsn.Errors)
+	assert.Equal(t, `block 2, line 8: Malformed field: "Action failed"`, dsn.Errors[0].Error())
+
+	msg, _ = mail.ReadMessage(strings.NewReader(value))
+	_, err = Parse(msg)
+	assert.Error(t, err)
+}
+
+func Test_parseReport_ContinueOnError(t *testing.T) {
+	type fixture struct {
+		value              string
+		expectedRecipients []string
+		expectedErrors     []*RecordError
+	}
+
+	fixtures := []fixture{
+		fixture{
+			value:              "Reporting-MTA: dns; a\r\n\r\n\r\nFinal-Recipient: rfc822; x@a\r\n",
+			expectedRecipients: []string{"x@a"},
+		},
+		fixture{
+			value:              " folded: start\nReporting-MTA: dns; a\n\nFinal-Recipient: rfc822; x@a",
+			expectedRecipients: []string{"x@a"},
+			expectedErrors: []*RecordError{
+				&RecordError{Block: 0, Line: 1, Raw: " folded: start", Err: ErrorMalformedField},
+			},
+		},
+		fixture{
+			value: "Reporting-MTA: dns; a\n\nFinal Recipient: rfc822; x@a\n\n: empty\n",
+			expectedErrors: []*RecordError{
+				&RecordError{Block: 1, Line: 3, Raw: "Final Recipient: rfc822; x@a", Err: ErrorMalformedField},
+				&RecordError{Block: 2, Line: 5, Raw: ": empty", Err: ErrorMalformedField},
+			},
+		},
+		fixture{
+			value: "",
+		},
+	}
+
+	for _, f := range fixtures {
+		dsn, err := (&walker{continueOnError: true}).parseReport(strings.NewReader(f.value))
+
+		if !assert.NoError(t, err, "Fixture: %q", f.value) {
+			continue
+		}
+
+		var recipients []string
+		for _, record := range dsn.Recipients {
+			recipients = append(recipients, record.FinalRecipient.Value)
+		}
+
+		assert.NotNil(t, dsn.Extensions, "Fixture: %q", f.value)
+		assert.Equal(t, f.expectedRecipients, recipients, "Fixture: %q", f.value)
+		assert.Equal(t, f.expectedErrors, dsn.Errors, "Fixture: %q", f.value)
+	}
+}
+
+func Test_Parse_MalformedField(t *testing.T) {
+	value := `From: Mail Delivery Subsystem <MAILER-DAEMON@example.com>
+Content-Type: multipart/report; report-type=delivery-status; boundary="b"
+
+--b
+Content-Type: text/plain
+
+Delivery failed.
+
+--b
+Content-Type: message/delivery-status
+
+Reporting-MTA: dns; mx.example.com
+
+Final-Recipient: rfc822; first@example.com
+Action: failed
+Status: 5.1.1
+
+Final-Recipient: rfc822; broken@example.com
+Action failed
+
+--b--
+`
+
+	msg, _ := mail.ReadMessage(strings.NewReader(value))
+	dsn, err := Parse(msg)
+
+	if assert.NotNil(t, dsn) {
+		assert.Len(t, dsn.Recipients, 1)
+	}
+
+	assert.True(t, errors.Is(err, ErrorMalformedField))
+	assert.EqualError(t, err, `rfc3464: part 2: line 8: block 2, line 8: Malformed field: "Action failed"`)
+
+	var parseErr *dsnerrors.ParseError
+	if assert.True(t, errors.As(err, &parseErr)) {
+		assert.Equal(t, "rfc3464", parseErr.Parser)
+		assert.Equal(t, "2", parseErr.Part)
+		assert.Equal(t, 8, parseErr.Line)
+	}
+
+	var recordErr *RecordError
+	if assert.True(t, errors.As(err, &recordErr)) {
+		assert.Equal(t, 2, recordErr.Block)
+	}
+}
@@ -0,0 +1,305 @@
+package rfc3464
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"sync"
+
+	"github.com/YouDoCom/go-maildsnparsers/dsnerrors"
+)
+
+/*
+Report represents RFC6522 multipart/report entity of any report-type
+
+	The multipart/report media type contains either two or three sub-
+	parts, in the following order:
+
+	1.  (REQUIRED) The first body part contains a human-readable message.
+	2.  (REQUIRED) A machine-parsable body part containing an account of
+	    the reported message handling event.
+	3.  (OPTIONAL) A body part containing the returned message or a
+	    portion thereof.
+*/
+type Report struct {
+	// ReportType is lower-cased report-type parameter, e.g. "delivery-status"
+	ReportType string
+
+	// Path is location of multipart/report entity in parsed message
+	Path PartPath
+
+	// HumanReadable is decoded UTF-8 text of the first part
+	HumanReadable string
+
+	// MediaType of machine-readable part, e.g. "message/delivery-status"
+	MediaType string
+
+	// Value is machine-readable part parsed by handler registered for
+	// ReportType, e.g. *DSN for "delivery-status". It is nil for unknown report types.
+	Value interface{}
+
+	// Raw is transfer-decoded body of machine-readable part of unknown report type
+	Raw []byte
+
+	// ReturnedMessage is original message or its header returned in the
+	// third part. It is nil if report has no such part.
+	ReturnedMessage *ReturnedMessage
+
+	// parsed is set when machine-readable part was passed to handler
+	parsed bool
+}
+
+// IsKnown checks that machine-readable part was parsed by registered handler.
+// Report without report-type parsed as delivery status is known too.
+func (r *Report) IsKnown() bool {
+	return r.parsed && r.Value != nil
+}
+
+// ReportHandler parses machine-readable part of multipart/report
+type ReportHandler struct {
+	// MediaTypes lists media types of machine-readable part,
+	// e.g. "message/delivery-status"
+	MediaTypes []string
+
+	// Parse parses transfer-decoded body of machine-readable part.
+	// Value is kept in Report even if error is returned.
+	Parse func(mediatype string, body io.Reader, opts ParseOptions) (interface{}, error)
+}
+
+func (h ReportHandler) accepts(mediatype string) bool {
+	for _, t := range h.MediaTypes {
+		if strings.EqualFold(t, mediatype) {
+			return true
+		}
+	}
+	return false
+}
+
+var deliveryStatusHandler = ReportHandler{
+	MediaTypes: []string{"message/delivery-status", "message/global-delivery-status"},
+	Parse: func(mediatype string, body io.Reader, opts ParseOptions) (interface{}, error) {
+		w := newWalker(opts)
+
+		dsn, err := w.parseReport(body)
+		if dsn != nil {
+			dsn.Global = mediatype == "message/global-delivery-status"
+			return dsn, err
+		}
+		return nil, err
+	},
+}
+
+var (
+	reportHandlersMu sync.RWMutex
+	reportHandlers   = map[string]ReportHandler{
+		"delivery-status":        deliveryStatusHandler,
+		"global-delivery-status": deliveryStatusHandler,
+	}
+)
+
+// RegisterReportHandler registers handler of multipart/report of report-type.
+// Report type is case-insensitive, handler registered earlier is replaced.
+func RegisterReportHandler(reportType string, handler ReportHandler) {
+	reportHandlersMu.Lock()
+	defer reportHandlersMu.Unlock()
+
+	reportHandlers[strings.ToLower(reportType)] = handler
+}
+
+// LookupReportHandler returns handler registered for report-type.
+// The second result is false if there is no such handler.
+func LookupReportHandler(reportType string) (ReportHandler, bool) {
+	reportHandlersMu.RLock()
+	defer reportHandlersMu.RUnlock()
+
+	handler, ok := reportHandlers[strings.ToLower(reportType)]
+	return handler, ok
+}
+
+// ReportTypeError returned by Parse when message contains multipart/report,
+// which is not delivery status report, e.g. read receipt or abuse report.
+// It matches ErrorDSNNotFound and ErrorUnsupportedReportType.
+type ReportTypeError struct {
+	// ReportType of found report
+	ReportType string
+}
+
+// Error returns error description
+func (e *ReportTypeError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrorUnsupportedReportType, e.ReportType)
+}
+
+// Unwrap returns ErrorUnsupportedReportType
+func (e *ReportTypeError) Unwrap() error {
+	return ErrorUnsupportedReportType
+}
+
+// isDeliveryStatusReport checks that report-type is delivery status.
+// Missing report-type is accepted, since some MTAs omit it.
+func isDeliveryStatusReport(reportType string) bool {
+	switch strings.ToLower(reportType) {
+	case "delivery-status", "global-delivery-status", "":
+		return true
+	}
+	return false
+}
+
+// ParseReport parses RFC6522 multipart/report entity of any report-type.
+//
+// Message is searched for multipart/report the same way as by ParseWithOptions.
+// Machine-readable part is parsed by handler registered for report-type,
+// report without report-type is parsed as delivery status.
+// For unknown report types Report.Value is nil and the second part
+// is kept in Report.Raw.
+func ParseReport(message *mail.Message, opts ParseOptions) (*Report, error) {
+	return parseReportMessage(message, opts, func(string) bool { return true })
+}
+
+// FindReport parses the first multipart/report of one of report types,
+// report-type is case-insensitive. It is used by parsers of other report
+// types built on top of this package. Error matching ErrorDSNNotFound
+// is returned when there is no such report in message.
+func FindReport(message *mail.Message, opts ParseOptions, reportTypes ...string) (*Report, error) {
+	return parseReportMessage(message, opts, func(reportType string) bool {
+		for _, t := range reportTypes {
+			if strings.EqualFold(t, reportType) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// parseReportMessage searches message for multipart/report which report-type is accepted
+func parseReportMessage(message *mail.Message, opts ParseOptions, accept func(reportType string) bool) (*Report, error) {
+	if message == nil {
+		return nil, dsnerrors.Wrap(parserName, "", 0, ErrorNilMessage)
+	}
+
+	hdr := textproto.MIMEHeader(message.Header)
+
+	if !isContainer(hdr) {
+		return nil, dsnerrors.Wrap(parserName, "", 0, ErrorInvalidContentTypeHeader)
+	}
+
+	w := newWalker(opts)
+	w.accept = accept
+
+	body := message.Body
+	if maxBytes := limit(opts.MaxBytes, DefaultMaxBytes); maxBytes > 0 {
+		body = newLimitReader(body, maxBytes, "MaxBytes")
+	}
+
+	report, err := w.walk(hdr, body, nil, 1)
+	if err == ErrorDSNPartNotFound && w.skipped != "" {
+		err = &ReportTypeError{ReportType: w.skipped}
+	}
+
+	return report, dsnerrors.Wrap(parserName, "", 0, err)
+}
+
+// parseMultipartReport reads parts of multipart/report entity: human-readable
+// text, machine-readable part and returned message which follows it.
+//
+// Errors are returned as ParseError located at part of the report.
+func (w *walker) parseMultipartReport(reportType, boundary string, reader io.Reader, path PartPath, depth int) (*Report, error) {
+	r := multipart.NewReader(reader, boundary)
+
+	handler, known := LookupReportHandler(reportType)
+	if reportType == "" {
+		// some MTAs omit report-type of delivery status reports
+		handler, known = deliveryStatusHandler, true
+	}
+
+	var (
+		report = Report{ReportType: strings.ToLower(reportType), Path: path}
+		found  bool
+	)
+
+	for n := 1; ; n++ {
+		p, err := r.NextPart()
+
+		if err != nil {
+			if err == io.EOF {
+				if !found {
+					return nil, ErrorDSNPartNotFound
+				}
+				return report.done(), nil
+			}
+
+			return report.partial(found), dsnerrors.Wrap(parserName, path.child(n).String(), 0, err)
+		}
+
+		if err := w.countPart(); err != nil {
+			return report.partial(found), dsnerrors.Wrap(parserName, path.child(n).String(), 0, err)
+		}
+
+		contentHeader := p.Header.Get("Content-Type")
+		mediatype, _, _ := mime.ParseMediaType(contentHeader)
+
+		// machine-readable part of unknown report is the second one
+		machine := handler.accepts(mediatype) || !known && n == 2
+
+		switch {
+		case n == 1 && !machine:
+			report.HumanReadable, err = w.readHumanReadable(p.Header, p, depth+1)
+			if err != nil {
+				return report.partial(found), dsnerrors.Wrap(parserName, path.child(n).String(), 0, err)
+			}
+		case !found && machine:
+			found = true
+			report.MediaType = mediatype
+
+			body, err := decodeTransferEncoding(p.Header, p)
+			if err != nil {
+				return nil, dsnerrors.Wrap(parserName, path.child(n).String(), 0, err)
+			}
+
+			if known {
+				report.parsed = true
+				report.Value, err = handler.Parse(mediatype, body, w.opts)
+			} else {
+				report.Raw, err = ioutil.ReadAll(body)
+			}
+
+			if err != nil {
+				var line int
+				if recordErr, ok := err.(*RecordError); ok {
+					line = recordErr.Line
+				}
+				return report.partial(found), dsnerrors.Wrap(parserName, path.child(n).String(), line, err)
+			}
+			if w.returned == ReturnedMessageSkip {
+				return report.done(), nil
+			}
+		case found && isReturnedMessage(mediatype):
+			report.ReturnedMessage, err = readReturnedMessage(mediatype, p, w.returned, w.maxHeaderBytes)
+			return report.done(), dsnerrors.Wrap(parserName, path.child(n).String(), 0, err)
+		}
+	}
+}
+
+// done copies report properties to DSN parsed from delivery status report
+func (r *Report) done() *Report {
+	if dsn, ok := r.Value.(*DSN); ok {
+		dsn.Path = r.Path
+		dsn.ReportType = r.ReportType
+		dsn.HumanReadable = r.HumanReadable
+		dsn.ReturnedMessage = r.ReturnedMessage
+	}
+	return r
+}
+
+// partial returns report read so far when error occurs,
+// nil is returned if machine-readable part was not found yet
+func (r *Report) partial(found bool) *Report {
+	if !found || r.Value == nil && r.Raw == nil {
+		return nil
+	}
+	return r.done()
+}
@@ -0,0 +1,406 @@
+package rfc3464
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"github.com/YouDoCom/go-maildsnparsers/dsnerrors"
+	"github.com/YouDoCom/go-maildsnparsers/internal/message"
+)
+
+// DefaultMaxReturnedBytes is default limit of returned message body size
+const DefaultMaxReturnedBytes = 64 << 10
+
+/*
+ReturnContent is RFC3461 RET parameter of MAIL command
+
+	The RET parameter is used to request that, in the event that a DSN
+	is issued for this recipient, either the full message content, or
+	only the headers of the message be returned along with the DSN.
+
+		ret-value = "FULL" / "HDRS"
+*/
+type ReturnContent int
+
+const (
+	// ReturnHeaders returns header of original message only, RET=HDRS.
+	// It is also used when RET parameter was not specified.
+	ReturnHeaders ReturnContent = iota
+
+	// ReturnFull returns whole original message, RET=FULL
+	ReturnFull
+)
+
+// ParseReturnContent parses value of RFC3461 RET parameter, "FULL" or "HDRS"
+func ParseReturnContent(value string) (ReturnContent, error) {
+	switch strings.ToUpper(strings.TrimSpace(value)) {
+	case "FULL":
+		return ReturnFull, nil
+	case "HDRS":
+		return ReturnHeaders, nil
+	}
+	return ReturnHeaders, ErrorInvalidReturnContent
+}
+
+/*
+ReportBuilder builds RFC6522 multipart/report message for DSN.
+
+The report consists of three parts:
+  - human-readable text/plain part produced by ReportLocale templates
+  - message/delivery-status part, see Marshal
+  - original message as message/rfc822 or its header as text/rfc822-headers,
+    depending on Return. Body of original message is truncated to MaxReturnedBytes.
+
+RFC6533 message/global-delivery-status, message/global and
+message/global-headers are used for Global DSN.
+*/
+type ReportBuilder struct {
+	// From is address of report sender. Empty value means
+	// "MAILER-DAEMON" at Reporting-MTA name.
+	From string
+
+	// To is address of report recipient, usually envelope sender of original message
+	To string
+
+	// Subject overrides subject produced by locale template
+	Subject string
+
+	// Locale is language tag of human-readable part and subject.
+	// Empty value means "en". See RegisterReportLocale.
+	Locale string
+
+	// Return selects whether whole original message or its header is returned
+	Return ReturnContent
+
+	// MaxReturnedBytes limits body size of returned original message,
+	// body is truncated at line boundary. Zero value means
+	// DefaultMaxReturnedBytes, negative disables the limit.
+	MaxReturnedBytes int64
+
+	// Date of report, zero value means current time
+	Date time.Time
+
+	// MessageID of report without angle brackets.
+	// Random ID at Reporting-MTA name is generated for empty value.
+	MessageID string
+
+	// Boundary of multipart/report, random boundary is used for empty value
+	Boundary string
+}
+
+// Build returns report message for DSN. Original message is read from original,
+// nil original means that report has no returned message part.
+//
+// DSN must be valid, Violations are returned otherwise.
+// Empty DSN.ReportType is not a violation.
+func (b *ReportBuilder) Build(dsn *DSN, original io.Reader) ([]byte, error) {
+	var buf bytes.Buffer
+
+	if err := b.Write(&buf, dsn, original); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Write writes report message for DSN to w. See Build.
+func (b *ReportBuilder) Write(w io.Writer, dsn *DSN, original io.Reader) error {
+	if dsn == nil {
+		return ErrorNilDSN
+	}
+
+	reportType, statusType := "delivery-status", "message/delivery-status"
+	if dsn.Global {
+		reportType, statusType = "global-delivery-status", "message/global-delivery-status"
+	}
+
+	checked := *dsn
+	if checked.ReportType == "" {
+		checked.ReportType = reportType
+	}
+	if v := Validate(&checked); v != nil {
+		return v
+	}
+
+	name := b.Locale
+	if name == "" {
+		name = "en"
+	}
+	locale, ok := LookupReportLocale(name)
+	if !ok {
+		return ErrorUnknownLocale
+	}
+
+	data := newReportData(dsn)
+
+	subject := b.Subject
+	if subject == "" {
+		var s strings.Builder
+		if err := locale.Subject.Execute(&s, data); err != nil {
+			return err
+		}
+		subject = strings.Join(strings.Fields(s.String()), " ")
+	}
+
+	var text bytes.Buffer
+	if err := locale.Text.Execute(&text, data); err != nil {
+		return err
+	}
+
+	status, err := Marshal(dsn)
+	if err != nil {
+		return err
+	}
+
+	var returned []byte
+	if original != nil {
+		if returned, err = b.readOriginal(original); err != nil {
+			return err
+		}
+	}
+
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	if b.Boundary != "" {
+		if err := mw.SetBoundary(b.Boundary); err != nil {
+			return err
+		}
+	}
+
+	if err := b.writeHeader(&buf, dsn, subject, reportType, mw.Boundary()); err != nil {
+		return err
+	}
+
+	if err := writeTextPart(mw, text.Bytes()); err != nil {
+		return err
+	}
+
+	if err := writePart(mw, statusType, status); err != nil {
+		return err
+	}
+
+	if original != nil {
+		if err := writePart(mw, b.returnedType(dsn.Global), returned); err != nil {
+			return err
+		}
+	}
+
+	if err := mw.Close(); err != nil {
+		return err
+	}
+
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+func (b *ReportBuilder) writeHeader(buf *bytes.Buffer, dsn *DSN, subject, reportType, boundary string) error {
+	from := b.From
+	if from == "" {
+		from = "MAILER-DAEMON@" + dsn.ReportingMTA.Value
+	}
+
+	date := b.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	id := b.MessageID
+	if id == "" {
+		random, err := randomHex(16)
+		if err != nil {
+			return err
+		}
+		id = random + "@" + dsn.ReportingMTA.Value
+	}
+
+	// line break would start another header field
+	for _, value := range []string{from, b.To, id} {
+		if strings.ContainsAny(value, "\r\n") {
+			return ErrorInvalidHeaderValue
+		}
+	}
+
+	// multipart writer writes nothing until the first part is created,
+	// so header goes before the body
+	buf.WriteString("From: " + from + "\r\n")
+	if b.To != "" {
+		buf.WriteString("To: " + b.To + "\r\n")
+	}
+	buf.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", subject) + "\r\n")
+	buf.WriteString("Date: " + date.Format(time.RFC1123Z) + "\r\n")
+	buf.WriteString("Message-ID: <" + id + ">\r\n")
+	buf.WriteString("Auto-Submitted: auto-replied\r\n")
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: " + mime.FormatMediaType("multipart/report", map[string]string{
+		"report-type": reportType,
+		"boundary":    boundary,
+	}) + "\r\n")
+	buf.WriteString("\r\n")
+
+	return nil
+}
+
+func (b *ReportBuilder) returnedType(global bool) string {
+	switch {
+	case b.Return == ReturnFull && global:
+		return "message/global"
+	case b.Return == ReturnFull:
+		return "message/rfc822"
+	case global:
+		return "message/global-headers"
+	}
+	return "text/rfc822-headers"
+}
+
+// readOriginal reads header of original message and, for ReturnFull,
+// its body truncated to MaxReturnedBytes. Line endings are converted to CRLF.
+func (b *ReportBuilder) readOriginal(original io.Reader) ([]byte, error) {
+	r := bufio.NewReader(original)
+
+	var (
+		out        bytes.Buffer
+		headerSize int
+	)
+
+	for {
+		line, err := message.ReadLine(r, DefaultMaxHeaderBytes-headerSize)
+		if err == message.ErrLineTooLong {
+			return nil, &dsnerrors.LimitError{Limit: "MaxHeaderBytes", Value: DefaultMaxHeaderBytes}
+		}
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		headerSize += len(line)
+
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			break
+		}
+		out.WriteString(line + "\r\n")
+
+		if err == io.EOF {
+			return out.Bytes(), nil
+		}
+	}
+
+	if b.Return != ReturnFull {
+		return out.Bytes(), nil
+	}
+
+	out.WriteString("\r\n")
+
+	max := limit(b.MaxReturnedBytes, DefaultMaxReturnedBytes)
+
+	var size int64
+	for {
+		remaining := -1
+		if max > 0 {
+			remaining = int(max - size)
+		}
+
+		// line longer than the rest of limit is not buffered
+		line, err := message.ReadLine(r, remaining)
+		if err == message.ErrLineTooLong {
+			break
+		}
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		if line == "" {
+			break
+		}
+
+		line = strings.TrimRight(line, "\r\n") + "\r\n"
+		size += int64(len(line))
+		if max > 0 && size > max {
+			break
+		}
+		out.WriteString(line)
+
+		if err == io.EOF {
+			break
+		}
+	}
+
+	return out.Bytes(), nil
+}
+
+// writeTextPart writes UTF-8 text/plain part, quoted-printable encoded if needed
+func writeTextPart(mw *multipart.Writer, text []byte) error {
+	text = bytes.Replace(text, []byte("\r\n"), []byte("\n"), -1)
+	text = bytes.Replace(text, []byte("\n"), []byte("\r\n"), -1)
+
+	hdr := textproto.MIMEHeader{}
+	hdr.Set("Content-Type", "text/plain; charset=utf-8")
+
+	if !is8bit(text) {
+		hdr.Set("Content-Transfer-Encoding", "7bit")
+
+		p, err := mw.CreatePart(hdr)
+		if err != nil {
+			return err
+		}
+		_, err = p.Write(text)
+		return err
+	}
+
+	hdr.Set("Content-Transfer-Encoding", "quoted-printable")
+
+	p, err := mw.CreatePart(hdr)
+	if err != nil {
+		return err
+	}
+
+	qp := quotedprintable.NewWriter(p)
+	if _, err := qp.Write(text); err != nil {
+		return err
+	}
+	return qp.Close()
+}
+
+// writePart writes part without transfer encoding,
+// which is not allowed for message/* types
+func writePart(mw *multipart.Writer, contentType string, data []byte) error {
+	hdr := textproto.MIMEHeader{}
+	hdr.Set("Content-Type", contentType)
+
+	if is8bit(data) {
+		hdr.Set("Content-Transfer-Encoding", "8bit")
+	} else {
+		hdr.Set("Content-Transfer-Encoding", "7bit")
+	}
+
+	p, err := mw.CreatePart(hdr)
+	if err != nil {
+		return err
+	}
+
+	_, err = p.Write(data)
+	return err
+}
+
+func is8bit(data []byte) bool {
+	for _, c := range data {
+		if c >= 0x80 {
+			return true
+		}
+	}
+	return false
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
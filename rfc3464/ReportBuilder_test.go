@@ -0,0 +1,253 @@
+package rfc3464
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testOriginalMessage = "From: sender@example.com\n" +
+	"To: user@example.net\n" +
+	"Subject: Hello\n" +
+	"Message-ID: <original@example.com>\n" +
+	"\n" +
+	"line 1\n" +
+	"line 2\n" +
+	"line 3\n"
+
+func testReportDSN() *DSN {
+	return &DSN{
+		ReportingMTA: TypeValueField{Type: "dns", Value: "mx.example.com"},
+		ArrivalDate:  "Thu, 7 Jul 1994 17:15:49 -0400",
+		Recipients: []RecipientRecord{
+			RecipientRecord{
+				FinalRecipient: TypeValueField{Type: "rfc822", Value: "user@example.net"},
+				Action:         RecipientAction("failed"),
+				Status:         "5.1.1",
+				DiagnosticCode: TypeValueField{Type: "smtp", Value: "550 5.1.1 User unknown"},
+			},
+		},
+	}
+}
+
+func Test_ParseReturnContent(t *testing.T) {
+	type fixture struct {
+		value    string
+		expected ReturnContent
+		err      error
+	}
+
+	fixtures := []fixture{
+		fixture{value: "FULL", expected: ReturnFull},
+		fixture{value: " hdrs ", expected: ReturnHeaders},
+		fixture{value: "BODY", expected: ReturnHeaders, err: ErrorInvalidReturnContent},
+	}
+
+	for _, f := range fixtures {
+		ret, err := ParseReturnContent(f.value)
+
+		assert.Equal(t, f.err, err, "Fixture: %q", f.value)
+		assert.Equal(t, f.expected, ret, "Fixture: %q", f.value)
+	}
+}
+
+func Test_ReportBuilder_Build(t *testing.T) {
+	builder := ReportBuilder{
+		To:        "sender@example.com",
+		Date:      time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+		MessageID: "report@mx.example.com",
+		Boundary:  "REPORT",
+		Return:    ReturnFull,
+	}
+
+	data, err := builder.Build(testReportDSN(), strings.NewReader(testOriginalMessage))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	report := string(data)
+	assert.True(t, strings.HasPrefix(report, "From: MAILER-DAEMON@mx.example.com\r\n"+
+		"To: sender@example.com\r\n"+
+		"Subject: Undelivered Mail Returned to Sender\r\n"+
+		"Date: Thu, 02 Jan 2020 03:04:05 +0000\r\n"+
+		"Message-ID: <report@mx.example.com>\r\n"+
+		"Auto-Submitted: auto-replied\r\n"+
+		"MIME-Version: 1.0\r\n"+
+		"Content-Type: multipart/report; boundary=REPORT; report-type=delivery-status\r\n"+
+		"\r\n"), report)
+	assert.Contains(t, report, "Content-Type: message/rfc822\r\n")
+	assert.NotContains(t, strings.Replace(report, "\r\n", "", -1), "\n")
+
+	dsn, err := ParseBytes(context.Background(), data, ParseOptions{ReturnedMessage: ReturnedMessageFull, Strict: true})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, "mx.example.com", dsn.ReportingMTA.Value)
+	assert.Equal(t, "550 5.1.1 User unknown", dsn.Recipients[0].DiagnosticCode.Value)
+	assert.Contains(t, dsn.HumanReadable, "<user@example.net>: 550 5.1.1 User unknown")
+	assert.Equal(t, "original@example.com", dsn.ReturnedMessage.MessageID())
+
+	body, _ := ioutil.ReadAll(dsn.ReturnedMessage.Body)
+	assert.Equal(t, "line 1\r\nline 2\r\nline 3\r\n", string(body))
+}
+
+func Test_ReportBuilder_Return(t *testing.T) {
+	type fixture struct {
+		name        string
+		builder     ReportBuilder
+		global      bool
+		contentType string
+		body        string
+	}
+
+	fixtures := []fixture{
+		fixture{
+			name:        "headers",
+			builder:     ReportBuilder{},
+			contentType: "text/rfc822-headers",
+		},
+		fixture{
+			name:        "truncated",
+			builder:     ReportBuilder{Return: ReturnFull, MaxReturnedBytes: 17},
+			contentType: "message/rfc822",
+			body:        "line 1\r\nline 2\r\n",
+		},
+		fixture{
+			name:        "unlimited",
+			builder:     ReportBuilder{Return: ReturnFull, MaxReturnedBytes: -1},
+			contentType: "message/rfc822",
+			body:        "line 1\r\nline 2\r\nline 3\r\n",
+		},
+		fixture{
+			name:        "global full",
+			builder:     ReportBuilder{Return: ReturnFull},
+			global:      true,
+			contentType: "message/global",
+			body:        "line 1\r\nline 2\r\nline 3\r\n",
+		},
+		fixture{
+			name:        "global headers",
+			builder:     ReportBuilder{},
+			global:      true,
+			contentType: "message/global-headers",
+		},
+	}
+
+	for _, f := range fixtures {
+		dsn := testReportDSN()
+		dsn.Global = f.global
+
+		data, err := f.builder.Build(dsn, strings.NewReader(testOriginalMessage))
+		if !assert.NoError(t, err, "Fixture: %s", f.name) {
+			continue
+		}
+
+		parsed, err := ParseBytes(context.Background(), data, ParseOptions{ReturnedMessage: ReturnedMessageFull})
+		if !assert.NoError(t, err, "Fixture: %s", f.name) {
+			continue
+		}
+
+		assert.Equal(t, f.global, parsed.Global, "Fixture: %s", f.name)
+		if !assert.NotNil(t, parsed.ReturnedMessage, "Fixture: %s", f.name) {
+			continue
+		}
+		assert.Equal(t, f.contentType, parsed.ReturnedMessage.ContentType, "Fixture: %s", f.name)
+		assert.Equal(t, "Hello", parsed.ReturnedMessage.Header.Get("Subject"), "Fixture: %s", f.name)
+
+		var body string
+		if parsed.ReturnedMessage.Body != nil {
+			data, _ := ioutil.ReadAll(parsed.ReturnedMessage.Body)
+			body = string(data)
+		}
+		assert.Equal(t, f.body, body, "Fixture: %s", f.name)
+	}
+}
+
+func Test_ReportBuilder_LongLine(t *testing.T) {
+	original := testOriginalMessage + strings.Repeat("x", 1<<20)
+
+	builder := ReportBuilder{Return: ReturnFull, MaxReturnedBytes: 100}
+	data, err := builder.Build(testReportDSN(), strings.NewReader(original))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	parsed, err := ParseBytes(context.Background(), data, ParseOptions{ReturnedMessage: ReturnedMessageFull})
+	if assert.NoError(t, err) && assert.NotNil(t, parsed.ReturnedMessage) {
+		body, _ := ioutil.ReadAll(parsed.ReturnedMessage.Body)
+		assert.Equal(t, "line 1\r\nline 2\r\nline 3\r\n", string(body))
+	}
+}
+
+func Test_ReportBuilder_HeaderInjection(t *testing.T) {
+	type fixture struct {
+		name    string
+		builder ReportBuilder
+		mta     string
+	}
+
+	fixtures := []fixture{
+		fixture{name: "from", builder: ReportBuilder{From: "postmaster@example.com\r\nBcc: victim@example.org"}},
+		fixture{name: "to", builder: ReportBuilder{To: "sender@example.com\nBcc: victim@example.org"}},
+		fixture{name: "message id", builder: ReportBuilder{MessageID: "id@example.com>\r\nX-Injected: <x"}},
+		fixture{name: "reporting mta", mta: "mx.example.com\r\nBcc: victim@example.org"},
+	}
+
+	for _, f := range fixtures {
+		dsn := testReportDSN()
+		if f.mta != "" {
+			dsn.ReportingMTA.Value = f.mta
+		}
+
+		_, err := f.builder.Build(dsn, nil)
+
+		assert.Equal(t, ErrorInvalidHeaderValue, err, "Fixture: %s", f.name)
+	}
+}
+
+func Test_ReportBuilder_Locale(t *testing.T) {
+	builder := ReportBuilder{Locale: "ru-RU"}
+
+	data, err := builder.Build(testReportDSN(), nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Contains(t, string(data), "Subject: =?utf-8?q?")
+	assert.Contains(t, string(data), "Content-Transfer-Encoding: quoted-printable")
+
+	dsn, err := ParseBytes(context.Background(), data, ParseOptions{})
+	if assert.NoError(t, err) {
+		assert.True(t, strings.HasPrefix(dsn.HumanReadable, "Это почтовая система узла mx.example.com."), dsn.HumanReadable)
+		assert.Nil(t, dsn.ReturnedMessage)
+	}
+
+	builder = ReportBuilder{Locale: "xx"}
+	_, err = builder.Build(testReportDSN(), nil)
+
+	assert.Equal(t, ErrorUnknownLocale, err)
+}
+
+func Test_ReportBuilder_Invalid(t *testing.T) {
+	builder := ReportBuilder{}
+
+	_, err := builder.Build(nil, nil)
+	assert.Equal(t, ErrorNilDSN, err)
+
+	dsn := testReportDSN()
+	dsn.Recipients[0].Action = ""
+
+	_, err = builder.Build(dsn, nil)
+
+	var violations Violations
+	if assert.True(t, errors.As(err, &violations)) {
+		assert.Len(t, violations, 1)
+		assert.Equal(t, ErrorRequiredFieldMissing, violations[0].Err)
+	}
+}
@@ -0,0 +1,134 @@
+package rfc3464
+
+import (
+	"strings"
+	"sync"
+	"text/template"
+)
+
+// ReportLocale holds templates of human-readable part of report built by ReportBuilder.
+// Both templates are executed with ReportData.
+type ReportLocale struct {
+	// Subject is template of Subject header, it must produce single line
+	Subject *template.Template
+	// Text is template of text/plain human-readable part
+	Text *template.Template
+}
+
+// ReportData is data passed to ReportLocale templates
+type ReportData struct {
+	// DSN is report being built
+	DSN *DSN
+	// ReportingMTA is name of MTA which issued the report
+	ReportingMTA string
+	// Failed lists recipients with "failed" action
+	Failed []RecipientRecord
+	// Delayed lists recipients with "delayed" action
+	Delayed []RecipientRecord
+	// Delivered lists recipients with "delivered", "relayed" or "expanded" action
+	Delivered []RecipientRecord
+}
+
+func newReportData(dsn *DSN) ReportData {
+	data := ReportData{DSN: dsn, ReportingMTA: dsn.ReportingMTA.Value}
+
+	for _, record := range dsn.Recipients {
+		switch {
+		case record.Action.IsFailed():
+			data.Failed = append(data.Failed, record)
+		case record.Action.IsDelayed():
+			data.Delayed = append(data.Delayed, record)
+		default:
+			data.Delivered = append(data.Delivered, record)
+		}
+	}
+
+	return data
+}
+
+var (
+	reportLocalesMu sync.RWMutex
+	reportLocales   = map[string]ReportLocale{
+		"en": mustReportLocale("en",
+			`{{if .Failed}}Undelivered Mail Returned to Sender`+
+				`{{else if .Delayed}}Delayed Mail (still being retried)`+
+				`{{else}}Successful Mail Delivery Report{{end}}`,
+			`This is the mail system at host {{.ReportingMTA}}.
+{{if .Failed}}
+I'm sorry to have to inform you that your message could not
+be delivered to one or more recipients.
+{{range .Failed}}
+<{{.FinalRecipient.Value}}>: {{if .DiagnosticCode.Value}}{{.DiagnosticCode.Value}}{{else}}{{.Status}}{{end}}
+{{end}}{{end}}{{if .Delayed}}
+Your message could not be delivered yet to the following recipients.
+The mail system will continue trying to deliver it.
+{{range .Delayed}}
+<{{.FinalRecipient.Value}}>: {{if .DiagnosticCode.Value}}{{.DiagnosticCode.Value}}{{else}}{{.Status}}{{end}}
+{{end}}{{end}}{{if .Delivered}}
+Your message was successfully delivered to the following recipients.
+{{range .Delivered}}
+<{{.FinalRecipient.Value}}>: {{.Action}}
+{{end}}{{end}}`),
+		"ru": mustReportLocale("ru",
+			`{{if .Failed}}Письмо не доставлено`+
+				`{{else if .Delayed}}Доставка письма задерживается`+
+				`{{else}}Отчёт о доставке письма{{end}}`,
+			`Это почтовая система узла {{.ReportingMTA}}.
+{{if .Failed}}
+К сожалению, ваше письмо не удалось доставить
+одному или нескольким получателям.
+{{range .Failed}}
+<{{.FinalRecipient.Value}}>: {{if .DiagnosticCode.Value}}{{.DiagnosticCode.Value}}{{else}}{{.Status}}{{end}}
+{{end}}{{end}}{{if .Delayed}}
+Ваше письмо пока не доставлено следующим получателям.
+Почтовая система продолжит попытки доставки.
+{{range .Delayed}}
+<{{.FinalRecipient.Value}}>: {{if .DiagnosticCode.Value}}{{.DiagnosticCode.Value}}{{else}}{{.Status}}{{end}}
+{{end}}{{end}}{{if .Delivered}}
+Ваше письмо успешно доставлено следующим получателям.
+{{range .Delivered}}
+<{{.FinalRecipient.Value}}>: {{.Action}}
+{{end}}{{end}}`),
+	}
+)
+
+func mustReportLocale(name, subject, text string) ReportLocale {
+	return ReportLocale{
+		Subject: template.Must(template.New(name + "-subject").Parse(subject)),
+		Text:    template.Must(template.New(name + "-text").Parse(text)),
+	}
+}
+
+// RegisterReportLocale adds or replaces locale used by ReportBuilder.
+// Name is case-insensitive language tag, e.g. "en" or "pt-BR".
+// Built-in locales are "en" and "ru".
+func RegisterReportLocale(name string, locale ReportLocale) {
+	reportLocalesMu.Lock()
+	defer reportLocalesMu.Unlock()
+
+	reportLocales[normalizeLocale(name)] = locale
+}
+
+// LookupReportLocale returns locale registered with name. When there is no
+// such locale, locale of its primary language is returned, e.g. "pt" for "pt-BR".
+// The second result is false if neither is registered.
+func LookupReportLocale(name string) (ReportLocale, bool) {
+	reportLocalesMu.RLock()
+	defer reportLocalesMu.RUnlock()
+
+	name = normalizeLocale(name)
+	if locale, ok := reportLocales[name]; ok {
+		return locale, true
+	}
+
+	if i := strings.IndexByte(name, '-'); i > 0 {
+		locale, ok := reportLocales[name[:i]]
+		return locale, ok
+	}
+
+	return ReportLocale{}, false
+}
+
+func normalizeLocale(name string) string {
+	return strings.ToLower(strings.Replace(strings.TrimSpace(name), "_", "-", -1))
+}
@@ -0,0 +1,62 @@
+package rfc3464
+
+import (
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_LookupReportLocale(t *testing.T) {
+	RegisterReportLocale("pt_BR", ReportLocale{
+		Subject: template.Must(template.New("subject").Parse("Relatório")),
+		Text:    template.Must(template.New("text").Parse("{{.ReportingMTA}}")),
+	})
+
+	type fixture struct {
+		name    string
+		subject string
+		ok      bool
+	}
+
+	fixtures := []fixture{
+		fixture{name: "en", subject: "Undelivered Mail Returned to Sender", ok: true},
+		fixture{name: "EN-us", subject: "Undelivered Mail Returned to Sender", ok: true},
+		fixture{name: "ru_RU", subject: "Письмо не доставлено", ok: true},
+		fixture{name: "pt-br", subject: "Relatório", ok: true},
+		fixture{name: "pt", ok: false},
+		fixture{name: "de", ok: false},
+	}
+
+	data := newReportData(testReportDSN())
+
+	for _, f := range fixtures {
+		locale, ok := LookupReportLocale(f.name)
+
+		if assert.Equal(t, f.ok, ok, "Fixture: %s", f.name) && ok {
+			var b strings.Builder
+			assert.NoError(t, locale.Subject.Execute(&b, data), "Fixture: %s", f.name)
+			assert.Equal(t, f.subject, b.String(), "Fixture: %s", f.name)
+		}
+	}
+}
+
+func Test_newReportData(t *testing.T) {
+	dsn := &DSN{
+		ReportingMTA: TypeValueField{Type: "dns", Value: "mx.example.com"},
+		Recipients: []RecipientRecord{
+			RecipientRecord{Action: RecipientAction("failed")},
+			RecipientRecord{Action: RecipientAction("Delayed")},
+			RecipientRecord{Action: RecipientAction("relayed")},
+			RecipientRecord{Action: RecipientAction("delivered")},
+		},
+	}
+
+	data := newReportData(dsn)
+
+	assert.Equal(t, "mx.example.com", data.ReportingMTA)
+	assert.Len(t, data.Failed, 1)
+	assert.Len(t, data.Delayed, 1)
+	assert.Len(t, data.Delivered, 2)
+}
@@ -0,0 +1,204 @@
+package rfc3464
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testMDNReport = `Content-Type: multipart/report; report-type=disposition-notification; boundary="MDN"
+
+--MDN
+Content-Type: text/plain
+
+The message was displayed.
+
+--MDN
+Content-Type: message/disposition-notification
+
+Reporting-UA: mua.example.com; Mailer
+Final-Recipient: rfc822; user@example.com
+Disposition: manual-action/MDN-sent-manually; displayed
+
+--MDN
+Content-Type: text/rfc822-headers
+
+Subject: Hello
+Message-ID: <original@example.com>
+
+--MDN--
+`
+
+func Test_ParseReport_Unknown(t *testing.T) {
+	msg, _ := mail.ReadMessage(strings.NewReader(testMDNReport))
+	report, err := ParseReport(msg, ParseOptions{})
+
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, "disposition-notification", report.ReportType)
+	assert.False(t, report.IsKnown())
+	assert.Nil(t, report.Value)
+	assert.Equal(t, "message/disposition-notification", report.MediaType)
+	assert.Contains(t, string(report.Raw), "Disposition: manual-action/MDN-sent-manually; displayed")
+	assert.Equal(t, "The message was displayed.", report.HumanReadable)
+	if assert.NotNil(t, report.ReturnedMessage) {
+		assert.Equal(t, "original@example.com", report.ReturnedMessage.MessageID())
+	}
+
+	msg, _ = mail.ReadMessage(strings.NewReader(testMDNReport))
+	dsn, err := Parse(msg)
+
+	assert.Nil(t, dsn)
+	assert.True(t, errors.Is(err, ErrorUnsupportedReportType))
+	assert.True(t, errors.Is(err, ErrorDSNNotFound))
+	assert.EqualError(t, err, "rfc3464: Report is not delivery status: disposition-notification")
+
+	var typeErr *ReportTypeError
+	if assert.True(t, errors.As(err, &typeErr)) {
+		assert.Equal(t, "disposition-notification", typeErr.ReportType)
+	}
+}
+
+func Test_ParseReport_DSN(t *testing.T) {
+	msg, _ := mail.ReadMessage(strings.NewReader(testNestedReport))
+	report, err := ParseReport(msg, ParseOptions{})
+
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.True(t, report.IsKnown())
+	assert.Equal(t, "message/delivery-status", report.MediaType)
+
+	dsn, ok := report.Value.(*DSN)
+	if assert.True(t, ok) {
+		assert.Equal(t, "delivery-status", dsn.ReportType)
+		assert.Equal(t, "Delivery failed.", dsn.HumanReadable)
+		assert.Len(t, dsn.Recipients, 1)
+	}
+}
+
+func Test_ParseReport_NoReportType(t *testing.T) {
+	value := strings.Replace(testNestedReport, "report-type=delivery-status; ", "", 1)
+
+	msg, _ := mail.ReadMessage(strings.NewReader(value))
+	report, err := ParseReport(msg, ParseOptions{})
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, "", report.ReportType)
+		assert.True(t, report.IsKnown())
+		assert.IsType(t, &DSN{}, report.Value)
+	}
+}
+
+func Test_Parse_UnexpectedReportValue(t *testing.T) {
+	RegisterReportHandler("delivery-status", ReportHandler{
+		MediaTypes: []string{"message/delivery-status"},
+		Parse: func(mediatype string, body io.Reader, opts ParseOptions) (interface{}, error) {
+			return "not a DSN", nil
+		},
+	})
+	defer RegisterReportHandler("delivery-status", deliveryStatusHandler)
+
+	msg, _ := mail.ReadMessage(strings.NewReader(testNestedReport))
+	dsn, err := Parse(msg)
+
+	assert.Nil(t, dsn)
+	assert.True(t, errors.Is(err, ErrorUnexpectedReportValue))
+	assert.EqualError(t, err, "rfc3464: Report value is not DSN")
+}
+
+func Test_RegisterReportHandler(t *testing.T) {
+	RegisterReportHandler("X-Test-Report", ReportHandler{
+		MediaTypes: []string{"message/x-test"},
+		Parse: func(mediatype string, body io.Reader, opts ParseOptions) (interface{}, error) {
+			data, err := ioutil.ReadAll(body)
+			return strings.TrimSpace(string(data)), err
+		},
+	})
+
+	value := `Content-Type: multipart/report; report-type=x-test-report; boundary="R"
+
+--R
+Content-Type: message/x-test
+
+test value
+--R--
+`
+
+	msg, _ := mail.ReadMessage(strings.NewReader(value))
+	report, err := ParseReport(msg, ParseOptions{})
+
+	if assert.NoError(t, err) {
+		assert.True(t, report.IsKnown())
+		assert.Equal(t, "test value", report.Value)
+		assert.Equal(t, "", report.HumanReadable)
+	}
+}
+
+func Test_Parse_SkipsOtherReports(t *testing.T) {
+	value := `From: user@example.com
+MIME-Version: 1.0
+Content-Type: multipart/mixed; boundary="MIXED"
+
+--MIXED
+` + testMDNReport + `
+--MIXED
+` + testNestedReport + `
+--MIXED--
+`
+
+	msg, _ := mail.ReadMessage(strings.NewReader(value))
+	dsn, err := Parse(msg)
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, "2", dsn.Path.String())
+		assert.Equal(t, "mx.example.com", dsn.ReportingMTA.Value)
+	}
+
+	msg, _ = mail.ReadMessage(strings.NewReader(value))
+	report, err := ParseReport(msg, ParseOptions{})
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, "1", report.Path.String())
+		assert.Equal(t, "disposition-notification", report.ReportType)
+	}
+}
+
+func Test_IsDSN_ReportType(t *testing.T) {
+	type fixture struct {
+		contentType string
+		reportType  string
+		isReport    bool
+		isDSN       bool
+	}
+
+	fixtures := []fixture{
+		fixture{contentType: `multipart/report; report-type=delivery-status; boundary=B`, reportType: "delivery-status", isReport: true, isDSN: true},
+		fixture{contentType: `multipart/report; report-type="Global-Delivery-Status"; boundary=B`, reportType: "global-delivery-status", isReport: true, isDSN: true},
+		fixture{contentType: `multipart/report; boundary=B`, reportType: "", isReport: true, isDSN: true},
+		fixture{contentType: `multipart/report; report-type=disposition-notification; boundary=B`, reportType: "disposition-notification", isReport: true},
+		fixture{contentType: `multipart/report; report-type=feedback-report; boundary=B`, reportType: "feedback-report", isReport: true},
+		fixture{contentType: `multipart/report; report-type=tlsrpt; boundary=B`, reportType: "tlsrpt", isReport: true},
+		fixture{contentType: `multipart/mixed; boundary=B`},
+	}
+
+	for _, f := range fixtures {
+		msg := &mail.Message{Header: mail.Header{"Content-Type": []string{f.contentType}}}
+
+		reportType, ok := DetectReportType(msg)
+
+		assert.Equal(t, f.isReport, ok, "Fixture: %s", f.contentType)
+		assert.Equal(t, f.reportType, reportType, "Fixture: %s", f.contentType)
+		assert.Equal(t, f.isDSN, IsDSN(msg), "Fixture: %s", f.contentType)
+	}
+
+	assert.False(t, IsDSN(nil))
+}
@@ -0,0 +1,94 @@
+package rfc3464
+
+import (
+	"bufio"
+	"io"
+	"mime/multipart"
+	"net/mail"
+	"net/textproto"
+	"strings"
+
+	"github.com/YouDoCom/go-maildsnparsers/dsnerrors"
+)
+
+// ReturnedMessageMode selects how much of returned original message is kept by parser
+type ReturnedMessageMode int
+
+const (
+	// ReturnedMessageHeaders keeps header of returned message only
+	ReturnedMessageHeaders ReturnedMessageMode = iota
+
+	// ReturnedMessageFull keeps header and lazily readable body of returned message
+	ReturnedMessageFull
+
+	// ReturnedMessageSkip does not read returned message at all
+	ReturnedMessageSkip
+)
+
+/*
+ReturnedMessage represents original message returned with DSN
+
+	The third component of a multipart/report is the original message or
+	some portion thereof.  When a message/delivery-status is used, the
+	returned content is either message/rfc822 (the whole message) or
+	text/rfc822-headers (the message header only).
+
+	RFC6533 internationalized reports use message/global and
+	message/global-headers respectively.
+*/
+type ReturnedMessage struct {
+	// ContentType is media type of returned part,
+	// e.g. "message/rfc822", "text/rfc822-headers" or "message/global"
+	ContentType string
+
+	// Header of returned message. It may be incomplete if
+	// reporting MTA truncated the original message.
+	Header mail.Header
+
+	// Body of returned message. It is set only in ReturnedMessageFull mode
+	// when returned part contains a body.
+	//
+	// Body reads directly from the body of parsed message, so it must be
+	// consumed before the message body is read by anything else.
+	Body io.Reader
+}
+
+// MessageID returns Message-ID of returned message without angle brackets
+func (m *ReturnedMessage) MessageID() string {
+	id := strings.TrimSpace(m.Header.Get("Message-Id"))
+	return strings.TrimSuffix(strings.TrimPrefix(id, "<"), ">")
+}
+
+func isReturnedMessage(mediatype string) bool {
+	switch mediatype {
+	case "message/rfc822", "text/rfc822-headers", "message/global", "message/global-headers":
+		return true
+	}
+	return false
+}
+
+func readReturnedMessage(mediatype string, p *multipart.Part, mode ReturnedMessageMode, maxHeaderBytes int) (*ReturnedMessage, error) {
+	body, err := decodeTransferEncoding(p.Header, p)
+	if err != nil {
+		return nil, err
+	}
+
+	r := textproto.NewReader(bufio.NewReader(newHeaderLimitReader(body, maxHeaderBytes)))
+
+	// header is kept even if it is malformed or truncated
+	hdr, err := r.ReadMIMEHeader()
+	if _, ok := err.(*dsnerrors.LimitError); ok {
+		return nil, err
+	}
+
+	msg := ReturnedMessage{
+		ContentType: mediatype,
+		Header:      mail.Header(hdr),
+	}
+
+	if mode == ReturnedMessageFull && isEncapsulatedMessage(mediatype) {
+		msg.Body = r.R
+	}
+
+	return &msg, nil
+}
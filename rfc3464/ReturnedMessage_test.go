@@ -0,0 +1,117 @@
+package rfc3464
+
+import (
+	"io/ioutil"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testReturnedMessageReport(contentType, content string) string {
+	return `From: MAILER-DAEMON@example.com
+MIME-Version: 1.0
+Content-Type: multipart/report; report-type=delivery-status; boundary="XXX"
+
+--XXX
+Content-Type: text/plain
+
+Delivery failed.
+
+--XXX
+Content-Type: message/delivery-status
+
+Reporting-MTA: dns; mx.example.com
+
+Final-Recipient: rfc822; user@example.com
+Action: failed
+Status: 5.1.1
+
+--XXX
+Content-Type: ` + contentType + `
+
+` + content + `
+--XXX--
+`
+}
+
+const testReturnedMessage = `Message-ID: <campaign-42@example.com>
+X-Campaign: spring-sale
+Subject: Hello
+From: sender@example.com
+
+Original body.
+`
+
+func Test_Parse_ReturnedMessageHeaders(t *testing.T) {
+	msg, _ := mail.ReadMessage(strings.NewReader(testReturnedMessageReport("message/rfc822", testReturnedMessage)))
+	dsn, err := Parse(msg)
+
+	if !assert.NoError(t, err) || !assert.NotNil(t, dsn.ReturnedMessage) {
+		return
+	}
+
+	assert.Equal(t, "message/rfc822", dsn.ReturnedMessage.ContentType)
+	assert.Equal(t, "campaign-42@example.com", dsn.ReturnedMessage.MessageID())
+	assert.Equal(t, "spring-sale", dsn.ReturnedMessage.Header.Get("X-Campaign"))
+	assert.Nil(t, dsn.ReturnedMessage.Body)
+}
+
+func Test_Parse_ReturnedMessageFull(t *testing.T) {
+	msg, _ := mail.ReadMessage(strings.NewReader(testReturnedMessageReport("message/rfc822", testReturnedMessage)))
+	dsn, err := ParseWithOptions(msg, ParseOptions{ReturnedMessage: ReturnedMessageFull})
+
+	if !assert.NoError(t, err) || !assert.NotNil(t, dsn.ReturnedMessage) {
+		return
+	}
+
+	assert.Equal(t, "Hello", dsn.ReturnedMessage.Header.Get("Subject"))
+	if assert.NotNil(t, dsn.ReturnedMessage.Body) {
+		body, err := ioutil.ReadAll(dsn.ReturnedMessage.Body)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Original body.\n", string(body))
+	}
+}
+
+func Test_Parse_ReturnedMessageHeadersPart(t *testing.T) {
+	msg, _ := mail.ReadMessage(strings.NewReader(testReturnedMessageReport("text/rfc822-headers", `Message-ID: <campaign-42@example.com>
+Subject: Hello
+`)))
+	dsn, err := ParseWithOptions(msg, ParseOptions{ReturnedMessage: ReturnedMessageFull})
+
+	if !assert.NoError(t, err) || !assert.NotNil(t, dsn.ReturnedMessage) {
+		return
+	}
+
+	assert.Equal(t, "text/rfc822-headers", dsn.ReturnedMessage.ContentType)
+	assert.Equal(t, "campaign-42@example.com", dsn.ReturnedMessage.MessageID())
+	assert.Nil(t, dsn.ReturnedMessage.Body)
+}
+
+func Test_Parse_ReturnedMessageEncoded(t *testing.T) {
+	msg, _ := mail.ReadMessage(strings.NewReader(testReturnedMessageReport("message/rfc822\nContent-Transfer-Encoding: base64",
+		"TWVzc2FnZS1JRDogPGNhbXBhaWduLTQyQGV4YW1wbGUuY29tPgoKYm9keQo=")))
+	dsn, err := Parse(msg)
+
+	if assert.NoError(t, err) && assert.NotNil(t, dsn.ReturnedMessage) {
+		assert.Equal(t, "campaign-42@example.com", dsn.ReturnedMessage.MessageID())
+	}
+}
+
+func Test_Parse_ReturnedMessageSkip(t *testing.T) {
+	msg, _ := mail.ReadMessage(strings.NewReader(testReturnedMessageReport("message/rfc822", testReturnedMessage)))
+	dsn, err := ParseWithOptions(msg, ParseOptions{ReturnedMessage: ReturnedMessageSkip})
+
+	assert.NoError(t, err)
+	assert.Nil(t, dsn.ReturnedMessage)
+}
+
+func Test_Parse_ReturnedMessageMissing(t *testing.T) {
+	msg, _ := mail.ReadMessage(strings.NewReader(testNestedReport))
+	dsn, err := Parse(msg)
+
+	assert.NoError(t, err)
+	assert.Nil(t, dsn.ReturnedMessage)
+}
@@ -0,0 +1,161 @@
+package rfc3464
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+/*
+SMTPDiagnostic represents parsed Diagnostic-Code field of "smtp" type
+
+	Reply-line     = *( Reply-code "-" [ textstring ] CRLF )
+	                 Reply-code [ SP textstring ] CRLF
+	Reply-code     = %x32-35 %x30-35 %x30-39
+
+Example:
+
+	Diagnostic-Code: smtp; 550-5.1.1 The email account that you tried to reach does
+	    not exist. 550-5.1.1 Please try double-checking the recipient's email
+	    550 5.1.1 address for typos.
+*/
+type SMTPDiagnostic struct {
+	// Code is basic SMTP reply code, e.g. 550
+	Code int
+	// EnhancedStatus is RFC3463 code following the reply code.
+	// Class is zero when reply has no enhanced status code.
+	EnhancedStatus EnhancedStatus
+	// Lines of reply text with reply and enhanced codes removed
+	Lines []string
+	// Prefix is text added by the reporting MTA before the reply,
+	// e.g. "host mx.example.com[192.0.2.1] said:"
+	Prefix string
+}
+
+// Text returns reply text joined to a single line
+func (d SMTPDiagnostic) Text() string {
+	return strings.Join(d.Lines, " ")
+}
+
+// HasEnhancedStatus checks that reply contains enhanced status code
+func (d SMTPDiagnostic) HasEnhancedStatus() bool {
+	return d.EnhancedStatus.Class.IsValid()
+}
+
+var smtpReplyRe = regexp.MustCompile(`(?:^|[\s:;(\[<])([2-5][0-5][0-9])(?:[ -]|$)(?:([245]\.[0-9]{1,3}\.[0-9]{1,3})(?:\s+|$))?`)
+
+// ParseSMTPDiagnostic parses SMTP reply from Diagnostic-Code value.
+//
+// Reply is looked up anywhere in the value, so text wrapped or prefixed
+// by the reporting MTA (e.g. "host mx.example.com said: 550 ...") is accepted.
+// Repeated "550-5.1.1" prefixes of multi-line replies are removed
+// at line starts and, with enhanced status code, inside unfolded lines.
+func ParseSMTPDiagnostic(value string) (SMTPDiagnostic, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return SMTPDiagnostic{}, ErrorFieldNotPresent
+	}
+
+	m := smtpReplyRe.FindStringSubmatchIndex(value)
+	if m == nil {
+		return SMTPDiagnostic{}, ErrorInvalidSMTPDiagnostic
+	}
+
+	var diag SMTPDiagnostic
+
+	diag.Code, _ = strconv.Atoi(value[m[2]:m[3]])
+	diag.Prefix = strings.TrimSpace(value[:m[2]])
+
+	end := m[1]
+	if m[4] >= 0 {
+		if status, err := ParseEnhancedStatus(value[m[4]:m[5]]); err == nil {
+			diag.EnhancedStatus = status
+		} else {
+			end = m[4]
+		}
+	}
+
+	code, status := value[m[2]:m[3]], ""
+	if diag.HasEnhancedStatus() {
+		status = diag.EnhancedStatus.String()
+	}
+
+	for _, line := range strings.Split(value[end:], "\n") {
+		for _, text := range splitReplyLine(line, code, status) {
+			if text = strings.TrimSpace(text); text != "" {
+				diag.Lines = append(diag.Lines, text)
+			}
+		}
+	}
+
+	return diag, nil
+}
+
+// splitReplyLine removes "code-" or "code " prefix of continuation line,
+// optionally followed by enhanced status code. Inside unfolded lines
+// the prefix is matched only with enhanced status code, so numbers
+// in reply text, e.g. "size 550 bytes", are kept.
+func splitReplyLine(line, code, status string) []string {
+	line = strings.TrimLeft(line, " \t\r\v\f")
+	if rest, ok := cutReplyCode(line, code); ok {
+		line = cutEnhancedStatus(rest, status)
+	}
+
+	if status == "" {
+		return []string{line}
+	}
+
+	var parts []string
+
+	for i := 1; i < len(line); i++ {
+		if !isReplySpace(line[i-1]) {
+			continue
+		}
+
+		rest, ok := cutReplyCode(line[i:], code)
+		if !ok {
+			continue
+		}
+		if after := cutEnhancedStatus(rest, status); after != rest {
+			parts = append(parts, line[:i])
+			line, i = after, 0
+		}
+	}
+
+	return append(parts, line)
+}
+
+// cutReplyCode removes code followed by "-", " " or end of line
+func cutReplyCode(line, code string) (string, bool) {
+	if !strings.HasPrefix(line, code) {
+		return line, false
+	}
+
+	rest := line[len(code):]
+	if rest == "" {
+		return rest, true
+	}
+	if rest[0] == ' ' || rest[0] == '-' {
+		return rest[1:], true
+	}
+
+	return line, false
+}
+
+// cutEnhancedStatus removes status followed by white space or end of line
+func cutEnhancedStatus(line, status string) string {
+	if status == "" || !strings.HasPrefix(line, status) {
+		return line
+	}
+
+	rest := line[len(status):]
+	if rest != "" && !isReplySpace(rest[0]) {
+		return line
+	}
+
+	return rest
+}
+
+func isReplySpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\v' || c == '\f'
+}
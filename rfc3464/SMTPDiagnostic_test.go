@@ -0,0 +1,126 @@
+package rfc3464
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseSMTPDiagnostic(t *testing.T) {
+	type fixture struct {
+		value         string
+		expected      SMTPDiagnostic
+		expectedError error
+	}
+
+	fixtures := []fixture{
+		fixture{
+			value: "550 5.1.1 <user@example.com>: Recipient address rejected",
+			expected: SMTPDiagnostic{
+				Code:           550,
+				EnhancedStatus: EnhancedStatus{Class: StatusClassPermanent, Subject: 1, Detail: 1},
+				Lines:          []string{"<user@example.com>: Recipient address rejected"},
+			},
+		},
+		fixture{
+			value: "550-5.1.1 The email account that you tried to reach does not exist. Please try 550-5.1.1 double-checking the recipient's email address for typos or 550 5.1.1 unnecessary spaces.",
+			expected: SMTPDiagnostic{
+				Code:           550,
+				EnhancedStatus: EnhancedStatus{Class: StatusClassPermanent, Subject: 1, Detail: 1},
+				Lines: []string{
+					"The email account that you tried to reach does not exist. Please try",
+					"double-checking the recipient's email address for typos or",
+					"unnecessary spaces.",
+				},
+			},
+		},
+		fixture{
+			value: "452-4.2.2 The email account is over quota.\n452 4.2.2 Please retry later.",
+			expected: SMTPDiagnostic{
+				Code:           452,
+				EnhancedStatus: EnhancedStatus{Class: StatusClassTransient, Subject: 2, Detail: 2},
+				Lines:          []string{"The email account is over quota.", "Please retry later."},
+			},
+		},
+		fixture{
+			value: "552-5.3.4 Message size 550 bytes over limit\n552 5.3.4 see 550 -policy",
+			expected: SMTPDiagnostic{
+				Code:           552,
+				EnhancedStatus: EnhancedStatus{Class: StatusClassPermanent, Subject: 3, Detail: 4},
+				Lines:          []string{"Message size 550 bytes over limit", "see 550 -policy"},
+			},
+		},
+		fixture{
+			value: "550 5.7.1 message size 550 bytes is too small",
+			expected: SMTPDiagnostic{
+				Code:           550,
+				EnhancedStatus: EnhancedStatus{Class: StatusClassPermanent, Subject: 7, Detail: 1},
+				Lines:          []string{"message size 550 bytes is too small"},
+			},
+		},
+		fixture{
+			value: "554 delivery error: dd This user doesn't have a yahoo.com account",
+			expected: SMTPDiagnostic{
+				Code:  554,
+				Lines: []string{"delivery error: dd This user doesn't have a yahoo.com account"},
+			},
+		},
+		fixture{
+			value: "host mx.example.com[192.0.2.1] said: 550 5.7.1 Message rejected as spam (in reply to end of DATA command)",
+			expected: SMTPDiagnostic{
+				Code:           550,
+				EnhancedStatus: EnhancedStatus{Class: StatusClassPermanent, Subject: 7, Detail: 1},
+				Lines:          []string{"Message rejected as spam (in reply to end of DATA command)"},
+				Prefix:         "host mx.example.com[192.0.2.1] said:",
+			},
+		},
+		fixture{
+			value: "Remote server replied: 421 Service not available",
+			expected: SMTPDiagnostic{
+				Code:   421,
+				Lines:  []string{"Service not available"},
+				Prefix: "Remote server replied:",
+			},
+		},
+		fixture{
+			value: "550 5.01.1 bad status",
+			expected: SMTPDiagnostic{
+				Code:  550,
+				Lines: []string{"5.01.1 bad status"},
+			},
+		},
+		fixture{
+			value:    "250",
+			expected: SMTPDiagnostic{Code: 250},
+		},
+		fixture{
+			value:         "Mailbox unavailable",
+			expectedError: ErrorInvalidSMTPDiagnostic,
+		},
+		fixture{
+			value:         "  ",
+			expectedError: ErrorFieldNotPresent,
+		},
+	}
+
+	for _, f := range fixtures {
+		got, err := ParseSMTPDiagnostic(f.value)
+
+		assert.Equal(t, f.expectedError, err, "Fixture: %q", f.value)
+		assert.Equal(t, f.expected, got, "Fixture: %q", f.value)
+	}
+}
+
+func Test_RecipientRecord_SMTPDiagnostic(t *testing.T) {
+	record := RecipientRecord{
+		DiagnosticCode: ParseTypeValueField("X-Postfix; host mx.example.com said: 550 5.1.1 User unknown"),
+	}
+
+	diag, err := record.SMTPDiagnostic()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 550, diag.Code)
+	assert.True(t, diag.HasEnhancedStatus())
+	assert.Equal(t, "5.1.1", diag.EnhancedStatus.String())
+	assert.Equal(t, "User unknown", diag.Text())
+}
@@ -0,0 +1,93 @@
+package rfc3464
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+/*
+DecodeUTF8Address decodes value of RFC6533 "utf-8" address type
+
+	utf-8-enc-addr      = utf-8-addr-xtext / utf-8-addr-unitext / utf-8-address
+	utf-8-addr-xtext    = 1*(QCHAR / EmbeddedUnicodeChar)
+	utf-8-addr-unitext  = 1*(QUCHAR / EmbeddedUnicodeChar)
+	EmbeddedUnicodeChar = %x5C.78 "{" HEXPOINT "}"
+
+Embedded "\x{HEXPOINT}" sequences are replaced with the characters they
+represent, raw UTF-8 characters of unitext form are kept as is.
+*/
+func DecodeUTF8Address(value string) (string, error) {
+	if !strings.Contains(value, `\x{`) {
+		if !utf8.ValidString(value) {
+			return "", ErrorInvalidAddressEncoding
+		}
+		return value, nil
+	}
+
+	var b strings.Builder
+
+	for {
+		i := strings.Index(value, `\x{`)
+		if i < 0 {
+			b.WriteString(value)
+			break
+		}
+
+		b.WriteString(value[:i])
+		value = value[i+3:]
+
+		end := strings.IndexByte(value, '}')
+		if end < 2 || end > 6 {
+			return "", ErrorInvalidAddressEncoding
+		}
+
+		code, err := strconv.ParseUint(value[:end], 16, 32)
+		if err != nil || !utf8.ValidRune(rune(code)) {
+			return "", ErrorInvalidAddressEncoding
+		}
+
+		b.WriteRune(rune(code))
+		value = value[end+1:]
+	}
+
+	if !utf8.ValidString(b.String()) {
+		return "", ErrorInvalidAddressEncoding
+	}
+
+	return b.String(), nil
+}
+
+// EncodeUTF8Address encodes value as RFC6533 utf-8-addr-xtext.
+// Characters other than QCHAR are replaced with "\x{HEXPOINT}" sequences.
+//
+//	QCHAR = %x21-2a / %x2c-3c / %x3e-5b / %x5d-7e
+func EncodeUTF8Address(value string) string {
+	var b strings.Builder
+
+	for _, r := range value {
+		if r >= '!' && r <= '~' && r != '+' && r != '=' && r != '\\' {
+			b.WriteRune(r)
+			continue
+		}
+
+		fmt.Fprintf(&b, `\x{%02X}`, r)
+	}
+
+	return b.String()
+}
+
+// decodeUTF8AddressField decodes value of "utf-8" typed field,
+// values which cannot be decoded are kept as is
+func decodeUTF8AddressField(field TypeValueField) TypeValueField {
+	if !strings.EqualFold(field.Type, "utf-8") {
+		return field
+	}
+
+	if value, err := DecodeUTF8Address(field.Value); err == nil {
+		field.Value = value
+	}
+
+	return field
+}
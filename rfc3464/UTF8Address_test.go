@@ -0,0 +1,90 @@
+package rfc3464
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DecodeUTF8Address(t *testing.T) {
+	type fixture struct {
+		value         string
+		expected      string
+		expectedError error
+	}
+
+	fixtures := []fixture{
+		fixture{
+			value:    "user@example.com",
+			expected: "user@example.com",
+		},
+		fixture{
+			value:    `\x{43F}\x{43E}\x{447}\x{442}\x{430}@\x{43F}\x{440}\x{438}\x{43C}\x{435}\x{440}.\x{440}\x{444}`,
+			expected: "почта@пример.рф",
+		},
+		fixture{
+			value:    `user\x{2B}tag@example.com`,
+			expected: "user+tag@example.com",
+		},
+		fixture{
+			value:    `почта@пример.рф`,
+			expected: "почта@пример.рф",
+		},
+		fixture{
+			value:    `почта\x{20}@x`,
+			expected: "почта @x",
+		},
+		fixture{
+			value:         `user\x{2B@example.com`,
+			expectedError: ErrorInvalidAddressEncoding,
+		},
+		fixture{
+			value:         `user\x{ZZ}@example.com`,
+			expectedError: ErrorInvalidAddressEncoding,
+		},
+		fixture{
+			value:         `user\x{D800}@example.com`,
+			expectedError: ErrorInvalidAddressEncoding,
+		},
+		fixture{
+			value:         "user\xff@example.com",
+			expectedError: ErrorInvalidAddressEncoding,
+		},
+	}
+
+	for _, f := range fixtures {
+		got, err := DecodeUTF8Address(f.value)
+
+		assert.Equal(t, f.expectedError, err, "Fixture: %q", f.value)
+		assert.Equal(t, f.expected, got, "Fixture: %q", f.value)
+	}
+}
+
+func Test_decodeUTF8AddressField(t *testing.T) {
+	got := decodeUTF8AddressField(TypeValueField{Type: "UTF-8", Value: `\x{44F}@example.com`})
+	assert.Equal(t, TypeValueField{Type: "UTF-8", Value: "я@example.com"}, got)
+
+	got = decodeUTF8AddressField(TypeValueField{Type: "rfc822", Value: `\x{44F}@example.com`})
+	assert.Equal(t, TypeValueField{Type: "rfc822", Value: `\x{44F}@example.com`}, got)
+
+	got = decodeUTF8AddressField(TypeValueField{Type: "utf-8", Value: `\x{ZZ}@example.com`})
+	assert.Equal(t, TypeValueField{Type: "utf-8", Value: `\x{ZZ}@example.com`}, got)
+}
+
+func Test_EncodeUTF8Address(t *testing.T) {
+	fixtures := map[string]string{
+		"user@example.com": "user@example.com",
+		"я@пример.рф":      `\x{44F}@\x{43F}\x{440}\x{438}\x{43C}\x{435}\x{440}.\x{440}\x{444}`,
+		"a+b=c\\d e\t":     `a\x{2B}b\x{3D}c\x{5C}d\x{20}e\x{09}`,
+		"😀@example.com":    `\x{1F600}@example.com`,
+	}
+
+	for value, expected := range fixtures {
+		got := EncodeUTF8Address(value)
+		assert.Equal(t, expected, got, "Value: %q", value)
+
+		decoded, err := DecodeUTF8Address(got)
+		assert.NoError(t, err)
+		assert.Equal(t, value, decoded)
+	}
+}
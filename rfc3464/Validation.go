@@ -0,0 +1,168 @@
+package rfc3464
+
+import (
+	"fmt"
+	"net/textproto"
+	"strings"
+)
+
+// Violation describes single deviation of report from RFC3464
+type Violation struct {
+	// Record is index of per-recipient record in DSN.Recipients,
+	// or -1 for per-message fields
+	Record int
+	// Field is name of the field, e.g. "Final-Recipient"
+	Field string
+	// Value of the field, empty for missing fields
+	Value string
+	// Err is one of ErrorRequiredFieldMissing, ErrorDuplicateField,
+	// ErrorInvalidAction, ErrorInvalidStatus, ErrorInvalidDateTime,
+	// ErrorUnknownReportType or ErrorNoRecipients
+	Err error
+}
+
+// Error returns description of violation
+func (v Violation) Error() string {
+	var where string
+	if v.Record < 0 {
+		where = "per-message fields"
+	} else {
+		where = fmt.Sprintf("recipient record %d", v.Record)
+	}
+
+	if v.Value != "" {
+		return fmt.Sprintf("%s: %s: %s: %q", where, v.Field, v.Err, v.Value)
+	}
+	if v.Field != "" {
+		return fmt.Sprintf("%s: %s: %s", where, v.Field, v.Err)
+	}
+	return fmt.Sprintf("%s: %s", where, v.Err)
+}
+
+// Violations is list of violations found by Validate.
+// It is returned as error by ParseWithOptions in strict mode.
+type Violations []Violation
+
+// Error returns descriptions of all violations
+func (v Violations) Error() string {
+	messages := make([]string, len(v))
+	for i, violation := range v {
+		messages[i] = violation.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+var (
+	perMessageFieldNames = fieldNames(
+		"Original-Envelope-Id", "Reporting-MTA", "DSN-Gateway",
+		"Received-From-MTA", "Arrival-Date",
+	)
+	perRecipientFieldNames = fieldNames(
+		"Original-Recipient", "Final-Recipient", "Action", "Status",
+		"Remote-MTA", "Diagnostic-Code", "Last-Attempt-Date",
+		"Final-Log-ID", "Will-Retry-Until",
+	)
+)
+
+// fieldNames maps canonical header keys to field names as spelled in RFC3464
+func fieldNames(names ...string) map[string]string {
+	m := make(map[string]string, len(names))
+	for _, name := range names {
+		m[textproto.CanonicalMIMEHeaderKey(name)] = name
+	}
+	return m
+}
+
+/*
+Validate checks DSN against RFC3464 and returns all violations found.
+Nil is returned for valid DSN.
+
+The following is checked:
+  - report-type parameter is "delivery-status" or "global-delivery-status"
+  - Reporting-MTA, Final-Recipient, Action and Status fields are present
+  - DSN has at least one per-recipient record
+  - single-instance fields are not repeated
+  - Action is one of "failed", "delayed", "delivered", "relayed", "expanded"
+  - Status is RFC3463 status code
+  - date fields are RFC5322 date-time
+
+Repeated fields are detected using Fields, which are filled by Parse.
+*/
+func Validate(dsn *DSN) Violations {
+	if dsn == nil {
+		return nil
+	}
+
+	var v Violations
+
+	switch strings.ToLower(dsn.ReportType) {
+	case "delivery-status", "global-delivery-status":
+	case "":
+		v = append(v, Violation{Record: -1, Field: "report-type", Err: ErrorRequiredFieldMissing})
+	default:
+		v = append(v, Violation{Record: -1, Field: "report-type", Value: dsn.ReportType, Err: ErrorUnknownReportType})
+	}
+
+	if dsn.ReportingMTA.Value == "" {
+		v = append(v, Violation{Record: -1, Field: "Reporting-MTA", Err: ErrorRequiredFieldMissing})
+	}
+
+	if dsn.ArrivalDate != "" {
+		if _, err := ParseDateTime(dsn.ArrivalDate); err != nil {
+			v = append(v, Violation{Record: -1, Field: "Arrival-Date", Value: dsn.ArrivalDate, Err: err})
+		}
+	}
+
+	for _, field := range dsn.RepeatedFields() {
+		v = append(v, Violation{Record: -1, Field: field, Err: ErrorDuplicateField})
+	}
+
+	if len(dsn.Recipients) == 0 {
+		v = append(v, Violation{Record: -1, Err: ErrorNoRecipients})
+	}
+
+	for i := range dsn.Recipients {
+		v = append(v, dsn.Recipients[i].validate(i)...)
+	}
+
+	return v
+}
+
+func (record *RecipientRecord) validate(index int) Violations {
+	var v Violations
+
+	if record.FinalRecipient.Value == "" {
+		v = append(v, Violation{Record: index, Field: "Final-Recipient", Err: ErrorRequiredFieldMissing})
+	}
+
+	if record.Action == "" {
+		v = append(v, Violation{Record: index, Field: "Action", Err: ErrorRequiredFieldMissing})
+	} else if !record.Action.IsValid() {
+		v = append(v, Violation{Record: index, Field: "Action", Value: string(record.Action), Err: ErrorInvalidAction})
+	}
+
+	if record.Status == "" {
+		v = append(v, Violation{Record: index, Field: "Status", Err: ErrorRequiredFieldMissing})
+	} else if _, err := record.EnhancedStatus(); err != nil {
+		v = append(v, Violation{Record: index, Field: "Status", Value: record.Status, Err: err})
+	}
+
+	dates := []struct{ field, value string }{
+		{"Last-Attempt-Date", record.LastAttemptDate},
+		{"Will-Retry-Until", record.WillRetryUntil},
+	}
+	for _, d := range dates {
+		if d.value == "" {
+			continue
+		}
+		if _, err := ParseDateTime(d.value); err != nil {
+			v = append(v, Violation{Record: index, Field: d.field, Value: d.value, Err: err})
+		}
+	}
+
+	for _, field := range record.RepeatedFields() {
+		v = append(v, Violation{Record: index, Field: field, Err: ErrorDuplicateField})
+	}
+
+	return v
+}
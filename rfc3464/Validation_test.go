@@ -0,0 +1,130 @@
+package rfc3464
+
+import (
+	"errors"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Validate(t *testing.T) {
+	valid := RecipientRecord{
+		FinalRecipient: TypeValueField{Type: "rfc822", Value: "user@example.com"},
+		Action:         RecipientAction("Failed"),
+		Status:         "5.1.1",
+	}
+
+	type fixture struct {
+		name     string
+		dsn      *DSN
+		expected Violations
+	}
+
+	fixtures := []fixture{
+		fixture{
+			name: "valid",
+			dsn: &DSN{
+				ReportType:   "delivery-status",
+				ReportingMTA: TypeValueField{Type: "dns", Value: "mx.example.com"},
+				Recipients:   []RecipientRecord{valid},
+			},
+		},
+		fixture{
+			name: "empty",
+			dsn:  &DSN{},
+			expected: Violations{
+				Violation{Record: -1, Field: "report-type", Err: ErrorRequiredFieldMissing},
+				Violation{Record: -1, Field: "Reporting-MTA", Err: ErrorRequiredFieldMissing},
+				Violation{Record: -1, Err: ErrorNoRecipients},
+			},
+		},
+		fixture{
+			name: "invalid values",
+			dsn: &DSN{
+				ReportType:   "disposition-notification",
+				ReportingMTA: TypeValueField{Type: "dns", Value: "mx.example.com"},
+				ArrivalDate:  "yesterday",
+				Recipients: []RecipientRecord{
+					valid,
+					RecipientRecord{
+						Action:          RecipientAction("bounced"),
+						Status:          "5.1",
+						LastAttemptDate: "Thu, 7 Jul 1994 17:15:49 -0400",
+						WillRetryUntil:  "tomorrow",
+					},
+					RecipientRecord{FinalRecipient: valid.FinalRecipient},
+				},
+			},
+			expected: Violations{
+				Violation{Record: -1, Field: "report-type", Value: "disposition-notification", Err: ErrorUnknownReportType},
+				Violation{Record: -1, Field: "Arrival-Date", Value: "yesterday", Err: ErrorInvalidDateTime},
+				Violation{Record: 1, Field: "Final-Recipient", Err: ErrorRequiredFieldMissing},
+				Violation{Record: 1, Field: "Action", Value: "bounced", Err: ErrorInvalidAction},
+				Violation{Record: 1, Field: "Status", Value: "5.1", Err: ErrorInvalidStatus},
+				Violation{Record: 1, Field: "Will-Retry-Until", Value: "tomorrow", Err: ErrorInvalidDateTime},
+				Violation{Record: 2, Field: "Action", Err: ErrorRequiredFieldMissing},
+				Violation{Record: 2, Field: "Status", Err: ErrorRequiredFieldMissing},
+			},
+		},
+	}
+
+	for _, f := range fixtures {
+		assert.Equal(t, f.expected, Validate(f.dsn), "Fixture: %s", f.name)
+	}
+}
+
+func Test_Violation_Error(t *testing.T) {
+	v := Violations{
+		Violation{Record: -1, Err: ErrorNoRecipients},
+		Violation{Record: 0, Field: "Action", Err: ErrorRequiredFieldMissing},
+		Violation{Record: 1, Field: "Status", Value: "5.1", Err: ErrorInvalidStatus},
+	}
+
+	assert.Equal(t, `per-message fields: No per-recipient fields; `+
+		`recipient record 0: Action: Required field missing; `+
+		`recipient record 1: Status: Invalid status code: "5.1"`, v.Error())
+}
+
+func Test_ParseWithOptions_Strict(t *testing.T) {
+	value := `From: Mail Delivery Subsystem <MAILER-DAEMON@example.com>
+Content-Type: multipart/report; report-type=delivery-status; boundary="b"
+
+--b
+Content-Type: text/plain
+
+Delivery failed.
+
+--b
+Content-Type: message/delivery-status
+
+Reporting-MTA: dns; mx.example.com
+Reporting-MTA: dns; mx2.example.com
+
+Final-Recipient: rfc822; user@example.com
+Action: failed
+Status: 5.1.1
+Status: 5.1.2
+
+--b--
+`
+
+	msg, _ := mail.ReadMessage(strings.NewReader(value))
+	dsn, err := ParseWithOptions(msg, ParseOptions{Strict: true})
+
+	assert.NotNil(t, dsn)
+	assert.Equal(t, "delivery-status", dsn.ReportType)
+
+	var violations Violations
+	if assert.True(t, errors.As(err, &violations)) {
+		assert.Equal(t, Violations{
+			Violation{Record: -1, Field: "Reporting-MTA", Err: ErrorDuplicateField},
+			Violation{Record: 0, Field: "Status", Err: ErrorDuplicateField},
+		}, violations)
+	}
+
+	msg, _ = mail.ReadMessage(strings.NewReader(value))
+	_, err = Parse(msg)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,162 @@
+package rfc3464
+
+import (
+	"mime"
+	"strings"
+)
+
+/*
+SupplementaryInfo represents Microsoft Exchange X-Supplementary-Info field
+
+Example:
+
+	X-Supplementary-Info: <mx.example.com #5.1.1 smtp;550 5.1.1 RESOLVER.ADR.RecipNotFound; not found>
+*/
+type SupplementaryInfo struct {
+	// Host which generated the status
+	Host string
+	// Status is enhanced status code
+	Status EnhancedStatus
+	// Diagnostic is typed diagnostic, e.g. "smtp; 550 5.1.1 ..."
+	Diagnostic TypeValueField
+}
+
+// ParseSupplementaryInfo parses value of X-Supplementary-Info field.
+// ErrorInvalidExtension is returned when value is not in Exchange format.
+func ParseSupplementaryInfo(value string) (SupplementaryInfo, error) {
+	value = strings.TrimSpace(value)
+	value = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(value, "<"), ">"))
+
+	i := strings.Index(value, "#")
+	if i < 0 {
+		return SupplementaryInfo{}, ErrorInvalidExtension
+	}
+
+	info := SupplementaryInfo{Host: strings.TrimSpace(value[:i])}
+
+	rest := value[i+1:]
+	code := rest
+	if j := strings.IndexAny(rest, " \t"); j >= 0 {
+		code, rest = rest[:j], rest[j:]
+	} else {
+		rest = ""
+	}
+
+	status, err := ParseEnhancedStatus(code)
+	if err != nil {
+		return SupplementaryInfo{}, ErrorInvalidExtension
+	}
+	info.Status = status
+
+	if rest = strings.TrimSpace(rest); rest != "" {
+		info.Diagnostic = ParseTypeValueField(rest)
+	}
+
+	return info, nil
+}
+
+// parseQueueID parses queue identifier, e.g. X-Postfix-Queue-ID field
+func parseQueueID(value string) (string, error) {
+	return strings.TrimSpace(stripComments(value)), nil
+}
+
+// parseExtensionAddress parses "address-type; address" extension field,
+// e.g. X-Postfix-Sender or X-Actual-Recipient
+func parseExtensionAddress(value string) (Address, error) {
+	return ParseAddress(ParseTypeValueField(stripComments(value)), false)
+}
+
+// parseDisplayName parses display name, RFC2047 encoded words are decoded
+func parseDisplayName(value string) (string, error) {
+	value = strings.TrimSpace(value)
+
+	decoded, err := new(mime.WordDecoder).DecodeHeader(value)
+	if err != nil {
+		return value, nil
+	}
+	return decoded, nil
+}
+
+// parseExtensionDiagnostic parses "diagnostic-type; text" extension field
+// containing SMTP reply, e.g. X-Exim-Diagnostic
+func parseExtensionDiagnostic(value string) (SMTPDiagnostic, error) {
+	return ParseSMTPDiagnostic(ParseTypeValueField(value).Value)
+}
+
+// PostfixQueueID returns value of Postfix X-Postfix-Queue-ID field.
+// ErrorFieldNotPresent is returned when field is absent.
+func (dsn *DSN) PostfixQueueID() (string, error) {
+	return extensionString(dsn.Extensions, "X-Postfix-Queue-ID")
+}
+
+// PostfixSender parses Postfix X-Postfix-Sender field
+func (dsn *DSN) PostfixSender() (Address, error) {
+	return extensionAddress(dsn.Extensions, "X-Postfix-Sender")
+}
+
+// DisplayName returns recipient name of Exchange X-Display-Name field
+func (record *RecipientRecord) DisplayName() (string, error) {
+	return extensionString(record.Extensions, "X-Display-Name")
+}
+
+// SupplementaryInfo parses Exchange X-Supplementary-Info field
+func (record *RecipientRecord) SupplementaryInfo() (SupplementaryInfo, error) {
+	value, err := record.Extensions.Parse("X-Supplementary-Info")
+	if err != nil {
+		return SupplementaryInfo{}, err
+	}
+
+	info, ok := value.(SupplementaryInfo)
+	if !ok {
+		return SupplementaryInfo{}, ErrorInvalidExtension
+	}
+	return info, nil
+}
+
+// ActualRecipient parses Sendmail X-Actual-Recipient field,
+// which contains recipient address after aliasing and forwarding
+func (record *RecipientRecord) ActualRecipient() (Address, error) {
+	return extensionAddress(record.Extensions, "X-Actual-Recipient")
+}
+
+// EximDiagnostic parses SMTP reply from Exim X-Exim-Diagnostic field
+func (record *RecipientRecord) EximDiagnostic() (SMTPDiagnostic, error) {
+	value, err := record.Extensions.Parse("X-Exim-Diagnostic")
+	if err != nil {
+		return SMTPDiagnostic{}, err
+	}
+
+	diagnostic, ok := value.(SMTPDiagnostic)
+	if !ok {
+		return SMTPDiagnostic{}, ErrorInvalidExtension
+	}
+	return diagnostic, nil
+}
+
+// extensionString parses extension field of string type with registered parser
+func extensionString(e Extensions, name string) (string, error) {
+	value, err := e.Parse(name)
+	if err != nil {
+		return "", err
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		return "", ErrorInvalidExtension
+	}
+	return s, nil
+}
+
+// extensionAddress parses extension field of Address type with registered parser
+func extensionAddress(e Extensions, name string) (Address, error) {
+	value, err := e.Parse(name)
+	if err != nil {
+		return Address{}, err
+	}
+
+	addr, ok := value.(Address)
+	if !ok {
+		return Address{}, ErrorInvalidExtension
+	}
+	return addr, nil
+}
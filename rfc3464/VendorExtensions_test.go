@@ -0,0 +1,85 @@
+package rfc3464
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseSupplementaryInfo(t *testing.T) {
+	type fixture struct {
+		value    string
+		expected SupplementaryInfo
+		err      error
+	}
+
+	fixtures := []fixture{
+		fixture{
+			value: "<mx.example.com #5.1.1 smtp;550 5.1.1 RESOLVER.ADR.RecipNotFound; not found>",
+			expected: SupplementaryInfo{
+				Host:       "mx.example.com",
+				Status:     EnhancedStatus{Class: StatusClassPermanent, Subject: 1, Detail: 1},
+				Diagnostic: TypeValueField{Type: "smtp", Value: "550 5.1.1 RESOLVER.ADR.RecipNotFound; not found"},
+			},
+		},
+		fixture{
+			value: "<[192.0.2.1] #4.4.7>",
+			expected: SupplementaryInfo{
+				Host:   "[192.0.2.1]",
+				Status: EnhancedStatus{Class: StatusClassTransient, Subject: 4, Detail: 7},
+			},
+		},
+		fixture{value: "Recipient not found", err: ErrorInvalidExtension},
+		fixture{value: "<mx.example.com #550 user unknown>", err: ErrorInvalidExtension},
+	}
+
+	for _, f := range fixtures {
+		info, err := ParseSupplementaryInfo(f.value)
+
+		assert.Equal(t, f.err, err, "Fixture: %q", f.value)
+		assert.Equal(t, f.expected, info, "Fixture: %q", f.value)
+	}
+}
+
+func Test_VendorExtensions(t *testing.T) {
+	dsn := DSN{Extensions: Extensions{}}
+	dsn.Extensions.Set("X-Postfix-Queue-ID", "3354017BFA8")
+	dsn.Extensions.Set("X-Postfix-Sender", "rfc822; Sender@Example.COM")
+
+	id, err := dsn.PostfixQueueID()
+	assert.NoError(t, err)
+	assert.Equal(t, "3354017BFA8", id)
+
+	sender, err := dsn.PostfixSender()
+	assert.NoError(t, err)
+	assert.Equal(t, "Sender@example.com", sender.Address)
+
+	record := RecipientRecord{Extensions: Extensions{}}
+
+	_, err = record.DisplayName()
+	assert.Equal(t, ErrorFieldNotPresent, err)
+
+	record.Extensions.Set("X-Display-Name", "=?utf-8?q?=D0=98=D0=B2=D0=B0=D0=BD?=")
+	record.Extensions.Set("X-Supplementary-Info", "<mx.example.com #5.1.1 smtp;550 5.1.1 not found>")
+	record.Extensions.Set("X-Actual-Recipient", "rfc822; alias@example.com")
+	record.Extensions.Set("X-Exim-Diagnostic", "X-str; SMTP error from remote mail server after RCPT TO:<user@example.com>:\n 550 5.1.1 User unknown")
+
+	name, err := record.DisplayName()
+	assert.NoError(t, err)
+	assert.Equal(t, "Иван", name)
+
+	info, err := record.SupplementaryInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, "5.1.1", info.Status.String())
+
+	actual, err := record.ActualRecipient()
+	assert.NoError(t, err)
+	assert.Equal(t, "alias@example.com", actual.Address)
+
+	diag, err := record.EximDiagnostic()
+	if assert.NoError(t, err) {
+		assert.Equal(t, 550, diag.Code)
+		assert.Equal(t, "5.1.1", diag.EnhancedStatus.String())
+		assert.Equal(t, "User unknown", diag.Text())
+	}
+}
@@ -0,0 +1,48 @@
+package rfc3464
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_decodeCharset(t *testing.T) {
+	type fixture struct {
+		charset  string
+		value    string
+		expected string
+	}
+
+	fixtures := []fixture{
+		fixture{charset: "", value: "plain", expected: "plain"},
+		fixture{charset: "UTF-8", value: "Привет", expected: "Привет"},
+		fixture{charset: "us-ascii", value: "bad \xff byte", expected: "bad � byte"},
+		fixture{charset: "latin1", value: "caf\xe9", expected: "café"},
+		fixture{charset: "windows-1252", value: "\x93quoted\x94 \x80", expected: "“quoted” €"},
+		fixture{charset: "cp1251", value: "\xcf\xf0\xe8\xe2\xe5\xf2", expected: "Привет"},
+		fixture{charset: "koi8-r", value: "\xf0\xd2\xc9\xd7\xc5\xd4", expected: "Привет"},
+	}
+
+	for _, f := range fixtures {
+		got, err := decodeCharset(f.charset, []byte(f.value), nil)
+
+		assert.NoError(t, err, "Fixture: %#v", f)
+		assert.Equal(t, f.expected, got, "Fixture: %#v", f)
+	}
+}
+
+func Test_decodeCharset_CharsetReader(t *testing.T) {
+	_, err := decodeCharset("x-woot", []byte("text"), nil)
+	assert.Equal(t, ErrorUnknownCharset, err)
+
+	charsetReader := func(charset string, input io.Reader) (io.Reader, error) {
+		assert.Equal(t, "x-woot", charset)
+		return strings.NewReader("decoded"), nil
+	}
+
+	got, err := decodeCharset("X-Woot", []byte("text"), charsetReader)
+	assert.NoError(t, err)
+	assert.Equal(t, "decoded", got)
+}
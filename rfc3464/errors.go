@@ -1,18 +1,101 @@
 package rfc3464
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/YouDoCom/go-maildsnparsers/dsnerrors"
+)
+
+// parserName is used as ParseError.Parser
+const parserName = "rfc3464"
 
 var (
 	// ErrorNilMessage returned when message is nil
-	ErrorNilMessage = errors.New("Message is nil")
+	ErrorNilMessage = dsnerrors.ErrorNilMessage
+
+	// ErrorDSNNotFound matches every error returned when message is not a DSN:
+	// ErrorInvalidContentTypeHeader, ErrorDSNPartNotFound and ErrorUnsupportedReportType
+	ErrorDSNNotFound = dsnerrors.ErrorDSNNotFound
 
 	// ErrorInvalidContentTypeHeader returned when Content-Type header not valid
 	//
 	// Valid examples:
 	// - multipart/report; report-type=delivery-status; boundary="RAA14128.773615765/CS.UTK.EDU"
 	// - multipart/report; report-type="delivery-status"; boundary="RAA14128.773615765/CS.UTK.EDU"
-	ErrorInvalidContentTypeHeader = errors.New("Invalid Content-Type header")
+	ErrorInvalidContentTypeHeader = dsnerrors.Derive(ErrorDSNNotFound, "Invalid Content-Type header")
 
 	// ErrorDSNPartNotFound retured when "message/delivery-status" part cannot be found in message body
-	ErrorDSNPartNotFound = errors.New("DSN part not found in message body")
+	ErrorDSNPartNotFound = dsnerrors.Derive(ErrorDSNNotFound, "DSN part not found in message body")
+
+	// ErrorUnsupportedReportType matches ReportTypeError returned when
+	// multipart/report is not delivery status report
+	ErrorUnsupportedReportType = dsnerrors.Derive(ErrorDSNNotFound, "Report is not delivery status")
+
+	// ErrorUnexpectedReportValue returned by Parse when handler registered
+	// for delivery status report-type does not return *DSN
+	ErrorUnexpectedReportValue = errors.New("Report value is not DSN")
+
+	// ErrorUnknownTransferEncoding returned when part has unsupported Content-Transfer-Encoding
+	ErrorUnknownTransferEncoding = dsnerrors.ErrorUnknownTransferEncoding
+
+	// ErrorUnknownCharset returned when text is in charset which cannot be decoded
+	ErrorUnknownCharset = dsnerrors.ErrorUnknownCharset
+
+	// ErrorLimitExceeded matches LimitError returned when message exceeds ParseOptions limits
+	ErrorLimitExceeded = dsnerrors.ErrorLimitExceeded
+
+	// ErrorInvalidAddressEncoding returned when encoded address cannot be decoded
+	ErrorInvalidAddressEncoding = errors.New("Invalid address encoding")
+
+	// ErrorInvalidAddress returned when recipient address is not valid mailbox
+	ErrorInvalidAddress = errors.New("Invalid address")
+
+	// ErrorInvalidStatus returned when Status field is not valid RFC3463 status code
+	ErrorInvalidStatus = errors.New("Invalid status code")
+
+	// ErrorInvalidSMTPDiagnostic returned when Diagnostic-Code field does not contain SMTP reply
+	ErrorInvalidSMTPDiagnostic = errors.New("Invalid SMTP diagnostic code")
+
+	// ErrorInvalidDateTime returned when date field is not valid RFC5322 date-time
+	ErrorInvalidDateTime = errors.New("Invalid date-time value")
+
+	// ErrorMalformedField returned when line of delivery-status part is not valid field
+	ErrorMalformedField = dsnerrors.ErrorMalformedField
+
+	// ErrorRequiredFieldMissing returned as violation when required field is absent
+	ErrorRequiredFieldMissing = dsnerrors.ErrorRequiredFieldMissing
+
+	// ErrorDuplicateField returned as violation when single-instance field is repeated
+	ErrorDuplicateField = errors.New("Duplicate field")
+
+	// ErrorInvalidAction returned as violation when Action field has undefined value
+	ErrorInvalidAction = errors.New("Invalid action value")
+
+	// ErrorUnknownReportType returned as violation when report-type is not delivery status
+	ErrorUnknownReportType = errors.New("Unknown report-type")
+
+	// ErrorNoRecipients returned as violation when DSN has no per-recipient fields
+	ErrorNoRecipients = errors.New("No per-recipient fields")
+
+	// ErrorFieldNotPresent returned when requested field is absent or empty
+	ErrorFieldNotPresent = errors.New("Field not present")
+
+	// ErrorNilDSN returned by ReportBuilder when DSN is nil
+	ErrorNilDSN = errors.New("DSN is nil")
+
+	// ErrorInvalidHeaderValue returned by ReportBuilder when From, To, MessageID
+	// or Reporting-MTA name used in report header contains CR or LF
+	ErrorInvalidHeaderValue = errors.New("Invalid header value")
+
+	// ErrorUnknownLocale returned by ReportBuilder when locale is not registered
+	ErrorUnknownLocale = errors.New("Unknown report locale")
+
+	// ErrorUnknownExtension returned when there is no parser registered for extension field
+	ErrorUnknownExtension = errors.New("Unknown extension field")
+
+	// ErrorInvalidExtension returned when extension field value cannot be parsed
+	ErrorInvalidExtension = errors.New("Invalid extension field value")
+
+	// ErrorInvalidReturnContent returned when RET parameter is neither "FULL" nor "HDRS"
+	ErrorInvalidReturnContent = errors.New("Invalid RET parameter value")
 )
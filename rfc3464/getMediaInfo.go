@@ -3,19 +3,25 @@ package rfc3464
 import (
 	"mime"
 	"net/mail"
+	"strings"
 )
 
-func getMediaInfo(hdr mail.Header) (string, error) {
+// mediaInfo is Content-Type parameters of multipart/report
+type mediaInfo struct {
+	boundary   string
+	reportType string
+}
+
+func getMediaInfo(hdr mail.Header) (mediaInfo, error) {
 	ctype := hdr.Get("Content-Type")
 	mediatype, params, err := mime.ParseMediaType(ctype)
 
 	if err == nil {
 		boundary := params["boundary"]
-		//if mediatype == "multipart/report" && params["report-type"] == "delivery-status" && boundary != "" {
 		if mediatype == "multipart/report" && boundary != "" {
-			return boundary, nil
+			return mediaInfo{boundary: boundary, reportType: strings.ToLower(params["report-type"])}, nil
 		}
 	}
 
-	return "", ErrorInvalidContentTypeHeader
+	return mediaInfo{}, ErrorInvalidContentTypeHeader
 }
@@ -10,7 +10,7 @@ import (
 func Test_getMediaInfo(t *testing.T) {
 	type fixture struct {
 		value         mail.Header
-		expected      string
+		expected      mediaInfo
 		expectedError error
 	}
 
@@ -19,13 +19,13 @@ func Test_getMediaInfo(t *testing.T) {
 			value: mail.Header{
 				"Content-Type": []string{"multipart/report; report-type=delivery-status; boundary=\"B525417BF12.1476910811/mail02.sample.com\""},
 			},
-			expected: `B525417BF12.1476910811/mail02.sample.com`,
+			expected: mediaInfo{boundary: `B525417BF12.1476910811/mail02.sample.com`, reportType: "delivery-status"},
 		},
 		fixture{
 			value: mail.Header{
 				"Content-Type": []string{`multipart/report; report-type=delivery-status; boundary=abcc`},
 			},
-			expected: `abcc`,
+			expected: mediaInfo{boundary: `abcc`, reportType: "delivery-status"},
 		},
 		fixture{
 			value: mail.Header{
@@ -37,7 +37,19 @@ func Test_getMediaInfo(t *testing.T) {
 			value: mail.Header{
 				"Content-Type": []string{`multipart/report; report-type=delivery-x; boundary=abcc`},
 			},
-			expected: `abcc`,
+			expected: mediaInfo{boundary: `abcc`, reportType: "delivery-x"},
+		},
+		fixture{
+			value: mail.Header{
+				"Content-Type": []string{`multipart/report; report-type=Disposition-Notification; boundary=abcc`},
+			},
+			expected: mediaInfo{boundary: `abcc`, reportType: "disposition-notification"},
+		},
+		fixture{
+			value: mail.Header{
+				"Content-Type": []string{`multipart/report; boundary=abcc`},
+			},
+			expected: mediaInfo{boundary: `abcc`},
 		},
 		fixture{
 			value: mail.Header{
@@ -62,10 +74,7 @@ func Test_getMediaInfo(t *testing.T) {
 	for _, f := range fixtures {
 		got, err := getMediaInfo(f.value)
 
-		if assert.Equal(t, f.expectedError, err, "Fixture: %#v", f) {
-			continue
-		}
-
+		assert.Equal(t, f.expectedError, err, "Fixture: %#v", f)
 		assert.Equal(t, f.expected, got, "Fixture: %#v", f)
 	}
 }
@@ -0,0 +1,95 @@
+package rfc3464
+
+import (
+	"io"
+
+	"github.com/YouDoCom/go-maildsnparsers/dsnerrors"
+)
+
+// limitReader reads at most limit bytes from r. Unlike io.LimitedReader
+// it returns LimitError instead of io.EOF when there is more data.
+// The error is sticky, since bufio.Reader discards errors on Peek.
+type limitReader struct {
+	r         io.Reader
+	remaining int64
+	limit     int64
+	name      string
+	err       error
+}
+
+func newLimitReader(r io.Reader, limit int64, name string) *limitReader {
+	return &limitReader{r: r, remaining: limit, limit: limit, name: name}
+}
+
+func (l *limitReader) Read(p []byte) (int, error) {
+	if l.err != nil {
+		return 0, l.err
+	}
+
+	if l.remaining <= 0 {
+		// input which ends exactly at the limit is fine,
+		// empty read without error is passed to caller to retry
+		var one [1]byte
+		if n, err := l.r.Read(one[:]); n == 0 {
+			return 0, err
+		}
+		l.err = &dsnerrors.LimitError{Limit: l.name, Value: l.limit}
+		return 0, l.err
+	}
+
+	if int64(len(p)) > l.remaining {
+		p = p[:l.remaining]
+	}
+
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	return n, err
+}
+
+// headerLimitReader returns LimitError if header section of entity,
+// which ends with the first blank line, is longer than limit bytes.
+// Bytes following the header are passed through without limit.
+// The error is sticky like in limitReader.
+type headerLimitReader struct {
+	r         io.Reader
+	limit     int
+	count     int
+	lineEmpty bool
+	done      bool
+	err       error
+}
+
+func newHeaderLimitReader(r io.Reader, limit int) io.Reader {
+	if limit <= 0 {
+		return r
+	}
+	return &headerLimitReader{r: r, limit: limit, lineEmpty: true}
+}
+
+func (h *headerLimitReader) Read(p []byte) (int, error) {
+	if h.err != nil {
+		return 0, h.err
+	}
+
+	n, err := h.r.Read(p)
+
+	for i := 0; i < n && !h.done; i++ {
+		h.count++
+
+		switch p[i] {
+		case '\n':
+			h.done = h.lineEmpty
+			h.lineEmpty = true
+		case '\r':
+		default:
+			h.lineEmpty = false
+		}
+
+		if !h.done && h.count > h.limit {
+			h.err = &dsnerrors.LimitError{Limit: "MaxHeaderBytes", Value: int64(h.limit)}
+			return 0, h.err
+		}
+	}
+
+	return n, err
+}
@@ -0,0 +1,248 @@
+package rfc3464
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"github.com/YouDoCom/go-maildsnparsers/dsnerrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_limitReader(t *testing.T) {
+	data, err := ioutil.ReadAll(newLimitReader(strings.NewReader("12345"), 5, "MaxBytes"))
+	assert.NoError(t, err)
+	assert.Equal(t, "12345", string(data))
+
+	data, err = ioutil.ReadAll(newLimitReader(strings.NewReader("123456"), 5, "MaxBytes"))
+	assert.Equal(t, &dsnerrors.LimitError{Limit: "MaxBytes", Value: 5}, err)
+	assert.Equal(t, "12345", string(data))
+}
+
+// emptyReadReader returns (0, nil) before every read of r
+type emptyReadReader struct {
+	r     io.Reader
+	empty bool
+}
+
+func (e *emptyReadReader) Read(p []byte) (int, error) {
+	if e.empty = !e.empty; e.empty {
+		return 0, nil
+	}
+	return e.r.Read(p)
+}
+
+func Test_limitReader_EmptyRead(t *testing.T) {
+	l := newLimitReader(&emptyReadReader{r: strings.NewReader("12345")}, 5, "MaxBytes")
+
+	data, err := ioutil.ReadAll(l)
+	assert.NoError(t, err)
+	assert.Equal(t, "12345", string(data))
+
+	l = newLimitReader(&emptyReadReader{r: strings.NewReader("123456")}, 5, "MaxBytes")
+
+	data, err = ioutil.ReadAll(l)
+	assert.Equal(t, &dsnerrors.LimitError{Limit: "MaxBytes", Value: 5}, err)
+	assert.Equal(t, "12345", string(data))
+}
+
+func Test_headerLimitReader(t *testing.T) {
+	type fixture struct {
+		value    string
+		limit    int
+		expected error
+	}
+
+	fixtures := []fixture{
+		fixture{value: "A: b\r\n\r\nbody of any length", limit: 8},
+		fixture{value: "A: b\n\nbody of any length", limit: 6},
+		fixture{value: "A: b\nC: d\n\nbody", limit: 6, expected: &dsnerrors.LimitError{Limit: "MaxHeaderBytes", Value: 6}},
+		fixture{value: "A: " + strings.Repeat("x", 100), limit: 64, expected: &dsnerrors.LimitError{Limit: "MaxHeaderBytes", Value: 64}},
+		fixture{value: "A: b\n\nbody", limit: 0},
+	}
+
+	for _, f := range fixtures {
+		_, err := ioutil.ReadAll(newHeaderLimitReader(strings.NewReader(f.value), f.limit))
+
+		assert.Equal(t, f.expected, err, "Fixture: %q", f.value)
+	}
+}
+
+func testLimitsReport(recipients int, extra string) string {
+	var b strings.Builder
+
+	b.WriteString(`From: MAILER-DAEMON@example.com
+Content-Type: multipart/report; report-type=delivery-status; boundary="b"
+
+--b
+Content-Type: message/delivery-status
+
+Reporting-MTA: dns; mx.example.com
+` + extra)
+
+	for i := 0; i < recipients; i++ {
+		fmt.Fprintf(&b, "\nFinal-Recipient: rfc822; user%d@example.com\nAction: failed\nStatus: 5.1.1\n", i)
+	}
+
+	b.WriteString("\n--b--\n")
+
+	return b.String()
+}
+
+func Test_ParseWithOptions_Limits(t *testing.T) {
+	type fixture struct {
+		value    string
+		opts     ParseOptions
+		expected string
+	}
+
+	fixtures := []fixture{
+		fixture{
+			value: testLimitsReport(3, ""),
+			opts:  ParseOptions{MaxRecipients: 3},
+		},
+		fixture{
+			value:    testLimitsReport(4, ""),
+			opts:     ParseOptions{MaxRecipients: 3},
+			expected: "rfc3464: part 1: Limit exceeded: MaxRecipients 3",
+		},
+		fixture{
+			value: testLimitsReport(DefaultMaxRecipients+1, ""),
+			opts:  ParseOptions{MaxRecipients: -1},
+		},
+		fixture{
+			value:    testLimitsReport(1, "X-Long: "+strings.Repeat("x", 2000)+"\n"),
+			opts:     ParseOptions{MaxHeaderBytes: 1024},
+			expected: "rfc3464: part 1: Limit exceeded: MaxHeaderBytes 1024",
+		},
+		fixture{
+			value:    testLimitsReport(1, ""),
+			opts:     ParseOptions{MaxParts: 1, ReturnedMessage: ReturnedMessageSkip},
+			expected: "",
+		},
+		fixture{
+			value:    testLimitsReport(20, ""),
+			opts:     ParseOptions{MaxBytes: 256},
+			expected: "rfc3464: part 1: Limit exceeded: MaxBytes 256",
+		},
+	}
+
+	for _, f := range fixtures {
+		msg, _ := mail.ReadMessage(strings.NewReader(f.value))
+		_, err := ParseWithOptions(msg, f.opts)
+
+		if f.expected == "" {
+			assert.NoError(t, err, "Options: %+v", f.opts)
+			continue
+		}
+
+		assert.True(t, errors.Is(err, ErrorLimitExceeded), "Options: %+v", f.opts)
+		assert.EqualError(t, err, f.expected, "Options: %+v", f.opts)
+	}
+}
+
+func Test_ParseWithOptions_MaxParts(t *testing.T) {
+	value := `From: MAILER-DAEMON@example.com
+Content-Type: multipart/mixed; boundary="m"
+
+--m
+Content-Type: text/plain
+
+one
+
+--m
+Content-Type: text/plain
+
+two
+
+--m
+` + testNestedReport + `
+--m--
+`
+
+	msg, _ := mail.ReadMessage(strings.NewReader(value))
+	_, err := ParseWithOptions(msg, ParseOptions{MaxParts: 2})
+
+	assert.EqualError(t, err, "rfc3464: part 3: Limit exceeded: MaxParts 2")
+
+	msg, _ = mail.ReadMessage(strings.NewReader(value))
+	_, err = ParseWithOptions(msg, ParseOptions{MaxParts: 5})
+
+	assert.NoError(t, err)
+}
+
+func Test_ParseWithOptions_HumanReadableLimits(t *testing.T) {
+	const levels = 200
+
+	var b strings.Builder
+	b.WriteString("From: MAILER-DAEMON@example.com\n")
+	b.WriteString("Content-Type: multipart/report; report-type=delivery-status; boundary=\"r\"\n\n")
+	b.WriteString("--r\n")
+	for i := 0; i < levels; i++ {
+		fmt.Fprintf(&b, "Content-Type: multipart/mixed; boundary=\"b%d\"\n\n--b%d\n", i, i)
+	}
+	b.WriteString("Content-Type: text/plain\n\nDelivery failed.\n")
+	for i := levels - 1; i >= 0; i-- {
+		fmt.Fprintf(&b, "--b%d--\n", i)
+	}
+	b.WriteString("--r\nContent-Type: message/delivery-status\n\n")
+	b.WriteString("Reporting-MTA: dns; mx.example.com\n\nFinal-Recipient: rfc822; user@example.com\nAction: failed\nStatus: 5.1.1\n\n--r--\n")
+	value := b.String()
+
+	type fixture struct {
+		opts     ParseOptions
+		expected string
+	}
+
+	fixtures := []fixture{
+		fixture{opts: ParseOptions{MaxDepth: 2, MaxParts: 5}, expected: "rfc3464: part 1: Limit exceeded: MaxDepth 2"},
+		fixture{opts: ParseOptions{MaxDepth: 500, MaxParts: 5}, expected: "rfc3464: part 1: Limit exceeded: MaxParts 5"},
+	}
+
+	for _, f := range fixtures {
+		msg, _ := mail.ReadMessage(strings.NewReader(value))
+		_, err := ParseWithOptions(msg, f.opts)
+
+		assert.True(t, errors.Is(err, ErrorLimitExceeded), "Options: %+v", f.opts)
+		assert.EqualError(t, err, f.expected, "Options: %+v", f.opts)
+	}
+
+	msg, _ := mail.ReadMessage(strings.NewReader(value))
+	dsn, err := ParseWithOptions(msg, ParseOptions{MaxDepth: levels + 2, MaxParts: levels + 2})
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, "Delivery failed.", dsn.HumanReadable)
+	}
+}
+
+func Test_ParseWithOptions_ReturnedHeaderLimit(t *testing.T) {
+	value := `From: MAILER-DAEMON@example.com
+Content-Type: multipart/report; report-type=delivery-status; boundary="b"
+
+--b
+Content-Type: message/delivery-status
+
+Reporting-MTA: dns; mx.example.com
+
+Final-Recipient: rfc822; user@example.com
+Action: failed
+Status: 5.1.1
+
+--b
+Content-Type: message/rfc822
+
+Subject: ` + strings.Repeat("x", 4000) + `
+
+body
+--b--
+`
+
+	msg, _ := mail.ReadMessage(strings.NewReader(value))
+	_, err := ParseWithOptions(msg, ParseOptions{MaxHeaderBytes: 1024})
+
+	assert.EqualError(t, err, "rfc3464: part 2: Limit exceeded: MaxHeaderBytes 1024")
+}
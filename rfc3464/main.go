@@ -1,94 +1,176 @@
 package rfc3464
 
 import (
-	"bufio"
+	"bytes"
+	"context"
 	"io"
-	"mime"
-	"mime/multipart"
 	"net/mail"
-	"net/textproto"
+
+	"github.com/YouDoCom/go-maildsnparsers/dsnerrors"
+	"github.com/YouDoCom/go-maildsnparsers/internal/message"
 )
 
-// Parse parses RFC3464 Delivery Status Notification (DSN) from mail message
-func Parse(message *mail.Message) (*DSN, error) {
-	if message == nil {
-		return nil, ErrorNilMessage
-	}
+// Default parse limits used for zero ParseOptions fields
+const (
+	// DefaultMaxDepth is default limit of MIME nesting depth searched for report
+	DefaultMaxDepth = 8
 
-	boundary, err := getMediaInfo(message.Header)
+	// DefaultMaxHeaderBytes is default limit of header section size
+	DefaultMaxHeaderBytes = message.DefaultMaxHeaderBytes
 
-	if err != nil {
-		return nil, err
-	}
+	// DefaultMaxRecipients is default limit of per-recipient records
+	DefaultMaxRecipients = 1000
 
-	r, err := findReport(boundary, message.Body)
+	// DefaultMaxParts is default limit of MIME parts read
+	DefaultMaxParts = 1000
 
-	if err != nil {
-		return nil, err
-	}
+	// DefaultMaxBytes is default limit of message body bytes read
+	DefaultMaxBytes = 64 << 20
+)
 
-	return parseReport(r)
+// ParseOptions controls how delivery status notification is parsed
+type ParseOptions struct {
+	// MaxDepth limits MIME nesting depth searched for multipart/report entity.
+	// The message itself has depth 1, so MaxDepth of 1 allows only top-level report.
+	// Zero value means DefaultMaxDepth. Deeper reports are not searched
+	// and LimitError is returned instead.
+	MaxDepth int
+
+	// MaxHeaderBytes limits size of every block of delivery-status fields
+	// and of header of encapsulated and returned messages.
+	// Zero value means DefaultMaxHeaderBytes, negative disables the limit.
+	MaxHeaderBytes int
+
+	// MaxRecipients limits number of per-recipient records.
+	// Zero value means DefaultMaxRecipients, negative disables the limit.
+	MaxRecipients int
+
+	// MaxParts limits total number of MIME parts read.
+	// Zero value means DefaultMaxParts, negative disables the limit.
+	MaxParts int
+
+	// MaxBytes limits total number of message body bytes read,
+	// including returned message body read by caller.
+	// Zero value means DefaultMaxBytes, negative disables the limit.
+	MaxBytes int64
+
+	// ReturnedMessage selects how much of returned original message is kept.
+	// Zero value keeps header only.
+	ReturnedMessage ReturnedMessageMode
+
+	// CharsetReader converts human-readable part from charsets
+	// which are not supported natively. See CharsetReader.
+	CharsetReader CharsetReader
+
+	// ContinueOnError skips malformed blocks of delivery-status part
+	// instead of failing. Well-formed records are returned and
+	// located errors of skipped blocks are stored in DSN.Errors.
+	ContinueOnError bool
+
+	// Strict enables RFC3464 validation of parsed report.
+	// When report is not valid, it is returned with Violations error.
+	Strict bool
 }
 
-func findReport(boundary string, reader io.Reader) (io.Reader, error) {
-	r := multipart.NewReader(reader, boundary)
-
-	for {
-		p, err := r.NextPart()
-
-		if err != nil {
-			if err == io.EOF {
-				return nil, ErrorDSNPartNotFound
-			}
-
-			return nil, err
-		}
+func (opts ParseOptions) maxDepth() int {
+	if opts.MaxDepth <= 0 {
+		return DefaultMaxDepth
+	}
+	return opts.MaxDepth
+}
 
-		contentHeader := p.Header.Get("Content-Type")
-		mediatype, _, err := mime.ParseMediaType(contentHeader)
-		if err == nil && mediatype == "message/delivery-status" {
-			return p, nil
-		}
+// limit returns value of limit option, or def for zero value.
+// Zero is returned for disabled limit.
+func limit(value, def int64) int64 {
+	switch {
+	case value == 0:
+		return def
+	case value < 0:
+		return 0
 	}
+	return value
 }
 
-func parseReport(reader io.Reader) (*DSN, error) {
-	r := textproto.NewReader(bufio.NewReader(reader))
-	hdr, err := r.ReadMIMEHeader()
+// Parse parses RFC3464 Delivery Status Notification (DSN) from mail message
+// with default options
+func Parse(message *mail.Message) (*DSN, error) {
+	return ParseWithOptions(message, ParseOptions{})
+}
 
+// ParseReader parses RFC3464 Delivery Status Notification (DSN) from raw message.
+//
+// Bare LF line endings, leading mbox "From " lines, UTF-8 byte order mark
+// and malformed top-level header lines are tolerated. Reading is stopped
+// when ctx is done.
+func ParseReader(ctx context.Context, r io.Reader, opts ParseOptions) (*DSN, error) {
+	msg, err := message.Read(ctx, r, message.HeaderLimit(opts.MaxHeaderBytes))
 	if err != nil {
-		return nil, err
+		return nil, dsnerrors.Wrap(parserName, "", 0, err)
 	}
 
-	dsn := DSN{}
-	dsn.fillFromHeader(hdr)
+	return ParseWithOptions(msg, opts)
+}
 
-	for {
-		hdr, err = r.ReadMIMEHeader()
+// ParseBytes parses RFC3464 Delivery Status Notification (DSN) from raw message.
+// See ParseReader.
+func ParseBytes(ctx context.Context, data []byte, opts ParseOptions) (*DSN, error) {
+	return ParseReader(ctx, bytes.NewReader(data), opts)
+}
 
-		if hdr != nil && len(hdr) > 0 {
-			record := RecipientRecord{}
-			record.fillFromHeader(hdr)
+// ParseWithOptions parses RFC3464 Delivery Status Notification (DSN) from mail message.
+//
+// The multipart/report entity is searched recursively, so reports forwarded as
+// message/rfc822 attachments or wrapped into other multipart containers are found too.
+// DSN.Path contains location of found report.
+// In strict mode DSN which violates RFC3464 is returned together with Violations error.
+func ParseWithOptions(message *mail.Message, opts ParseOptions) (*DSN, error) {
+	report, err := parseReportMessage(message, opts, isDeliveryStatusReport)
+
+	var dsn *DSN
+	if report != nil {
+		dsn, _ = report.Value.(*DSN)
+	}
 
-			dsn.Recipients = append(dsn.Recipients, record)
-		}
+	if err != nil {
+		return dsn, err
+	}
 
-		if err != nil {
-			if err == io.EOF {
-				err = nil
-			}
+	if dsn == nil {
+		return nil, dsnerrors.Wrap(parserName, report.Path.String(), 0, ErrorUnexpectedReportValue)
+	}
 
-			return &dsn, err
+	if opts.Strict {
+		if violations := Validate(dsn); violations != nil {
+			return dsn, dsnerrors.Wrap(parserName, dsn.Path.String(), 0, violations)
 		}
 	}
+
+	return dsn, nil
 }
 
-// IsDSN checks that message is valid RFC3464 Delivery Status Notification (DSN)
+// IsDSN checks that message is valid RFC3464 Delivery Status Notification (DSN):
+// multipart/report with "delivery-status" or "global-delivery-status" report-type.
+// Report without report-type is accepted too. Other reports, e.g. read receipts
+// or abuse reports, are not DSN.
+//
+// See DetectReportType.
 func IsDSN(message *mail.Message) bool {
+	reportType, ok := DetectReportType(message)
+	return ok && isDeliveryStatusReport(reportType)
+}
+
+// DetectReportType returns lower-cased report-type parameter of message,
+// which is multipart/report. The second result is false for other messages.
+// Only message header is inspected, so reports nested into other
+// containers are not detected. Use Parse to find them.
+func DetectReportType(message *mail.Message) (string, bool) {
 	if message == nil {
-		return false
+		return "", false
 	}
 
-	_, err := getMediaInfo(message.Header)
-	return err == nil
+	info, err := getMediaInfo(message.Header)
+	if err != nil {
+		return "", false
+	}
+	return info.reportType, true
 }
@@ -1,6 +1,8 @@
 package rfc3464
 
 import (
+	"context"
+	"errors"
 	"net/mail"
 	"strings"
 	"testing"
@@ -189,7 +191,8 @@ content-type: message/rfc822
 func Test_Parse_NilMessage(t *testing.T) {
 	_, err := Parse(nil)
 
-	assert.Equal(t, ErrorNilMessage, err)
+	assert.True(t, errors.Is(err, ErrorNilMessage))
+	assert.EqualError(t, err, "rfc3464: Message is nil")
 }
 
 func Test_IsDSN_Valid(t *testing.T) {
@@ -286,7 +289,8 @@ recipients. This is a permanent error. The following address(es) failed:
 	msg, _ := mail.ReadMessage(strings.NewReader(value))
 
 	_, err := Parse(msg)
-	assert.EqualError(t, err, ErrorInvalidContentTypeHeader.Error())
+	assert.True(t, errors.Is(err, ErrorInvalidContentTypeHeader))
+	assert.True(t, errors.Is(err, ErrorDSNNotFound))
 }
 
 func Test_Parse_InvalidDSNPartNotFound(t *testing.T) {
@@ -323,7 +327,8 @@ content-type: message/rfc822
 	msg, _ := mail.ReadMessage(strings.NewReader(value))
 
 	_, err := Parse(msg)
-	assert.EqualError(t, err, ErrorDSNPartNotFound.Error())
+	assert.True(t, errors.Is(err, ErrorDSNPartNotFound))
+	assert.True(t, errors.Is(err, ErrorDSNNotFound))
 }
 
 func TestReaderError(t *testing.T) {
@@ -386,3 +391,172 @@ content-type: message/rfc822
 	_, err := Parse(msg)
 	assert.Error(t, err)
 }
+
+func Test_Parse_TransferEncoding(t *testing.T) {
+	type fixture struct {
+		encoding string
+		body     string
+	}
+
+	fixtures := []fixture{
+		fixture{
+			encoding: "base64",
+			body: `UmVwb3J0aW5nLU1UQTogZG5zOyBteC5leGFtcGxlLmNvbQ0KDQpGaW5hbC1S
+ZWNpcGllbnQ6IHJmYzgyMjsgdXNlckBleGFtcGxlLmNvbQ0KQWN0aW9uOiBm
+YWlsZWQNClN0YXR1czogNS4xLjENCg==`,
+		},
+		fixture{
+			encoding: "quoted-printable",
+			body: `Reporting-MTA: dns; mx.example=
+.com
+
+Final-Recipient: rfc822; user=40example.com
+Action: failed
+Status: 5.1.1`,
+		},
+	}
+
+	for _, f := range fixtures {
+		value := `From: Mail Delivery Subsystem <MAILER-DAEMON@example.com>
+MIME-Version: 1.0
+Content-Type: multipart/report; report-type=delivery-status; boundary="XXX"
+
+--XXX
+
+Delivery failed.
+
+--XXX
+Content-Type: message/delivery-status
+Content-Transfer-Encoding: ` + f.encoding + `
+
+` + f.body + `
+
+--XXX--
+`
+
+		msg, _ := mail.ReadMessage(strings.NewReader(value))
+		dsn, err := Parse(msg)
+
+		if !assert.NoError(t, err, "Encoding: %s", f.encoding) {
+			continue
+		}
+
+		assert.Equal(t, "mx.example.com", dsn.ReportingMTA.Value, "Encoding: %s", f.encoding)
+		if assert.Len(t, dsn.Recipients, 1, "Encoding: %s", f.encoding) {
+			assert.Equal(t, "user@example.com", dsn.Recipients[0].FinalRecipient.Value, "Encoding: %s", f.encoding)
+			assert.Equal(t, "5.1.1", dsn.Recipients[0].Status, "Encoding: %s", f.encoding)
+		}
+	}
+}
+
+func Test_Parse_UnknownTransferEncoding(t *testing.T) {
+	value := `From: Mail Delivery Subsystem <MAILER-DAEMON@example.com>
+MIME-Version: 1.0
+Content-Type: multipart/report; report-type=delivery-status; boundary="XXX"
+
+--XXX
+Content-Type: message/delivery-status
+Content-Transfer-Encoding: x-woot
+
+Reporting-MTA: dns; mx.example.com
+
+--XXX--
+`
+
+	msg, _ := mail.ReadMessage(strings.NewReader(value))
+	_, err := Parse(msg)
+
+	assert.True(t, errors.Is(err, ErrorUnknownTransferEncoding))
+	assert.EqualError(t, err, "rfc3464: part 1: Unknown Content-Transfer-Encoding")
+}
+
+func Test_Parse_GlobalDeliveryStatus(t *testing.T) {
+	value := `From: MAILER-DAEMON@почта.example
+MIME-Version: 1.0
+Content-Type: multipart/report; report-type=global-delivery-status; boundary="XXX"
+
+--XXX
+Content-Type: text/plain; charset=utf-8
+
+Сообщение не доставлено.
+
+--XXX
+Content-Type: message/global-delivery-status
+Content-Transfer-Encoding: 8bit
+
+Reporting-MTA: dns; почта.example
+
+Original-Recipient: utf-8; \x{43F}\x{43E}\x{447}\x{442}\x{430}@почта.example
+Final-Recipient: utf-8; почта@почта.example
+Action: failed
+Status: 5.1.1
+Diagnostic-Code: smtp; 550 Ящик не найден
+
+--XXX
+Content-Type: message/global-headers
+
+Message-ID: <id@почта.example>
+Subject: Привет
+
+--XXX--
+`
+
+	msg, _ := mail.ReadMessage(strings.NewReader(value))
+	dsn, err := Parse(msg)
+
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.True(t, dsn.Global)
+	assert.Equal(t, "почта.example", dsn.ReportingMTA.Value)
+	assert.Equal(t, "Сообщение не доставлено.", dsn.HumanReadable)
+
+	if assert.Len(t, dsn.Recipients, 1) {
+		record := dsn.Recipients[0]
+
+		assert.Equal(t, "почта@почта.example", record.OriginalRecipient.Value)
+		assert.Equal(t, "почта@почта.example", record.FinalRecipient.Value)
+		assert.Equal(t, "550 Ящик не найден", record.DiagnosticCode.Value)
+	}
+
+	if assert.NotNil(t, dsn.ReturnedMessage) {
+		assert.Equal(t, "message/global-headers", dsn.ReturnedMessage.ContentType)
+		assert.Equal(t, "Привет", dsn.ReturnedMessage.Header.Get("Subject"))
+	}
+}
+
+func Test_ParseBytes(t *testing.T) {
+	value := "\xEF\xBB\xBFFrom MAILER-DAEMON Thu Jul  7 17:16:05 1994\n" +
+		"From: Mail Delivery Subsystem <MAILER-DAEMON@example.com>\n" +
+		"broken header line\n" +
+		"Content-Type: multipart/report; report-type=delivery-status;\n" +
+		" boundary=\"b\"\n" +
+		"\n" +
+		"--b\n" +
+		"Content-Type: message/delivery-status\n" +
+		"\n" +
+		"Reporting-MTA: dns; mx.example.com\n" +
+		"\n" +
+		"Final-Recipient: rfc822; user@example.com\n" +
+		"Action: failed\n" +
+		"Status: 5.1.1\n" +
+		"\n" +
+		"--b--\n"
+
+	dsn, err := ParseBytes(context.Background(), []byte(value), ParseOptions{})
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, "mx.example.com", dsn.ReportingMTA.Value)
+		assert.Equal(t, "user@example.com", dsn.Recipients[0].FinalRecipient.Value)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = ParseReader(ctx, strings.NewReader(value), ParseOptions{})
+	assert.True(t, errors.Is(err, context.Canceled))
+
+	_, err = ParseBytes(context.Background(), []byte("Subject: test\n\nbody"), ParseOptions{})
+	assert.True(t, errors.Is(err, ErrorDSNNotFound))
+}
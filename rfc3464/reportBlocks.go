@@ -0,0 +1,55 @@
+package rfc3464
+
+import (
+	"io"
+	"net/textproto"
+
+	"github.com/YouDoCom/go-maildsnparsers/internal/fieldblock"
+)
+
+// parseReport parses delivery-status part block by block.
+// Parsing stops at the first malformed block and *RecordError is returned
+// with fields read so far. In ContinueOnError mode malformed blocks
+// are skipped and their errors are stored in DSN.Errors.
+func (w *walker) parseReport(reader io.Reader) (*DSN, error) {
+	blocks, err := fieldblock.Read(reader, w.maxHeaderBytes, w.maxRecipients)
+	if err != nil {
+		return nil, err
+	}
+
+	dsn := DSN{}
+
+	for i, block := range blocks {
+		fields, recordErr := fieldblock.Parse(i, block)
+		if recordErr != nil {
+			if !w.continueOnError {
+				if i == 0 {
+					return nil, recordErr
+				}
+				return &dsn, recordErr
+			}
+
+			dsn.Errors = append(dsn.Errors, recordErr)
+			if i == 0 {
+				dsn.fillFromHeader(textproto.MIMEHeader{})
+			}
+			continue
+		}
+
+		if i == 0 {
+			dsn.fillFromFields(fields)
+			continue
+		}
+
+		record := RecipientRecord{}
+		record.fillFromFields(fields)
+
+		dsn.Recipients = append(dsn.Recipients, record)
+	}
+
+	if len(blocks) == 0 {
+		dsn.fillFromHeader(textproto.MIMEHeader{})
+	}
+
+	return &dsn, nil
+}
@@ -0,0 +1,48 @@
+package rfc3464
+
+import (
+	"encoding/base64"
+	"io"
+	"mime/quotedprintable"
+	"net/textproto"
+	"strings"
+)
+
+// decodeTransferEncoding returns reader decoding body according to
+// Content-Transfer-Encoding header of the part
+func decodeTransferEncoding(hdr textproto.MIMEHeader, r io.Reader) (io.Reader, error) {
+	encoding := strings.ToLower(strings.TrimSpace(hdr.Get("Content-Transfer-Encoding")))
+
+	switch encoding {
+	case "", "7bit", "8bit", "binary":
+		return r, nil
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, &whitespaceFilteringReader{r: r}), nil
+	case "quoted-printable":
+		return quotedprintable.NewReader(r), nil
+	}
+
+	return nil, ErrorUnknownTransferEncoding
+}
+
+// whitespaceFilteringReader drops spaces, tabs and line breaks
+// which are not allowed in base64 data but often added by MTAs
+type whitespaceFilteringReader struct {
+	r io.Reader
+}
+
+func (f *whitespaceFilteringReader) Read(p []byte) (int, error) {
+	for {
+		n, err := f.r.Read(p)
+		offset := 0
+		for _, b := range p[:n] {
+			if b != ' ' && b != '\t' && b != '\r' && b != '\n' {
+				p[offset] = b
+				offset++
+			}
+		}
+		if offset > 0 || err != nil {
+			return offset, err
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package rfc3464
+
+import (
+	"io/ioutil"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_decodeTransferEncoding(t *testing.T) {
+	type fixture struct {
+		encoding      string
+		value         string
+		expected      string
+		expectedError error
+	}
+
+	fixtures := []fixture{
+		fixture{
+			encoding: "",
+			value:    "Action: failed",
+			expected: "Action: failed",
+		},
+		fixture{
+			encoding: "7bit",
+			value:    "Action: failed",
+			expected: "Action: failed",
+		},
+		fixture{
+			encoding: "8BIT",
+			value:    "Action: failed",
+			expected: "Action: failed",
+		},
+		fixture{
+			encoding: "binary",
+			value:    "Action: failed",
+			expected: "Action: failed",
+		},
+		fixture{
+			encoding: "base64",
+			value:    "QWN0aW9u\r\nOiBmYWls \r\nZWQ=\r\n",
+			expected: "Action: failed",
+		},
+		fixture{
+			encoding: " Quoted-Printable ",
+			value:    "Diagnostic-Code: smtp; 550 user=\r\n unknown =3D bad",
+			expected: "Diagnostic-Code: smtp; 550 user unknown = bad",
+		},
+		fixture{
+			encoding:      "x-uuencode",
+			value:         "Action: failed",
+			expectedError: ErrorUnknownTransferEncoding,
+		},
+	}
+
+	for _, f := range fixtures {
+		hdr := textproto.MIMEHeader{}
+		hdr.Set("Content-Transfer-Encoding", f.encoding)
+
+		r, err := decodeTransferEncoding(hdr, strings.NewReader(f.value))
+
+		if !assert.Equal(t, f.expectedError, err, "Fixture: %#v", f) || err != nil {
+			continue
+		}
+
+		got, err := ioutil.ReadAll(r)
+
+		assert.NoError(t, err, "Fixture: %#v", f)
+		assert.Equal(t, f.expected, string(got), "Fixture: %#v", f)
+	}
+}
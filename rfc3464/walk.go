@@ -0,0 +1,156 @@
+package rfc3464
+
+import (
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"net/textproto"
+	"strings"
+
+	"github.com/YouDoCom/go-maildsnparsers/dsnerrors"
+	"github.com/YouDoCom/go-maildsnparsers/internal/message"
+)
+
+// walker searches MIME tree of message for multipart/report entity
+// which contains delivery status
+type walker struct {
+	maxDepth       int
+	maxHeaderBytes int
+	maxRecipients  int
+	maxParts       int
+
+	// parts is number of MIME parts read so far
+	parts int
+
+	returned ReturnedMessageMode
+
+	charsetReader CharsetReader
+
+	continueOnError bool
+
+	// opts are passed to report handlers
+	opts ParseOptions
+
+	// accept selects report types to parse, nil accepts every report
+	accept func(reportType string) bool
+	// skipped is the first report type not accepted
+	skipped string
+}
+
+func newWalker(opts ParseOptions) *walker {
+	return &walker{
+		maxDepth:       opts.maxDepth(),
+		maxHeaderBytes: message.HeaderLimit(opts.MaxHeaderBytes),
+		maxRecipients:  int(limit(int64(opts.MaxRecipients), DefaultMaxRecipients)),
+		maxParts:       int(limit(int64(opts.MaxParts), DefaultMaxParts)),
+
+		returned:      opts.ReturnedMessage,
+		charsetReader: opts.CharsetReader,
+
+		continueOnError: opts.ContinueOnError,
+
+		opts: opts,
+	}
+}
+
+// walk inspects entity and its descendants. It returns ErrorDSNPartNotFound
+// if there is no accepted report in the entity.
+func (w *walker) walk(hdr textproto.MIMEHeader, body io.Reader, path PartPath, depth int) (*Report, error) {
+	mediatype, params, err := mime.ParseMediaType(hdr.Get("Content-Type"))
+	if err != nil {
+		return nil, ErrorDSNPartNotFound
+	}
+
+	switch {
+	case mediatype == "multipart/report" && params["boundary"] != "":
+		reportType := params["report-type"]
+		if w.accept != nil && !w.accept(reportType) {
+			if w.skipped == "" {
+				w.skipped = strings.ToLower(reportType)
+			}
+			return nil, ErrorDSNPartNotFound
+		}
+		return w.parseMultipartReport(reportType, params["boundary"], body, path, depth)
+	case strings.HasPrefix(mediatype, "multipart/") && params["boundary"] != "":
+		if depth >= w.maxDepth {
+			return nil, dsnerrors.Wrap(parserName, path.String(), 0, w.depthError())
+		}
+		return w.walkMultipart(params["boundary"], body, path, depth)
+	case isEncapsulatedMessage(mediatype):
+		if depth >= w.maxDepth {
+			return nil, dsnerrors.Wrap(parserName, path.String(), 0, w.depthError())
+		}
+
+		r, err := decodeTransferEncoding(hdr, body)
+		if err != nil {
+			return nil, dsnerrors.Wrap(parserName, path.String(), 0, err)
+		}
+
+		msg, err := mail.ReadMessage(newHeaderLimitReader(r, w.maxHeaderBytes))
+		if _, ok := err.(*dsnerrors.LimitError); ok {
+			return nil, dsnerrors.Wrap(parserName, path.String(), 0, err)
+		}
+		if err != nil {
+			return nil, ErrorDSNPartNotFound
+		}
+
+		return w.walk(textproto.MIMEHeader(msg.Header), msg.Body, path, depth+1)
+	}
+
+	return nil, ErrorDSNPartNotFound
+}
+
+func (w *walker) walkMultipart(boundary string, body io.Reader, path PartPath, depth int) (*Report, error) {
+	r := multipart.NewReader(body, boundary)
+
+	for n := 1; ; n++ {
+		p, err := r.NextPart()
+
+		if err != nil {
+			if err == io.EOF {
+				return nil, ErrorDSNPartNotFound
+			}
+
+			return nil, dsnerrors.Wrap(parserName, path.String(), 0, err)
+		}
+
+		if err := w.countPart(); err != nil {
+			return nil, dsnerrors.Wrap(parserName, path.child(n).String(), 0, err)
+		}
+
+		report, err := w.walk(p.Header, p, path.child(n), depth+1)
+		if err != ErrorDSNPartNotFound {
+			return report, err
+		}
+	}
+}
+
+// countPart counts MIME part read and checks MaxParts limit
+func (w *walker) countPart() error {
+	w.parts++
+	if w.maxParts > 0 && w.parts > w.maxParts {
+		return &dsnerrors.LimitError{Limit: "MaxParts", Value: int64(w.maxParts)}
+	}
+	return nil
+}
+
+func (w *walker) depthError() error {
+	return &dsnerrors.LimitError{Limit: "MaxDepth", Value: int64(w.maxDepth)}
+}
+
+// isContainer checks that entity may contain delivery status report
+func isContainer(hdr textproto.MIMEHeader) bool {
+	mediatype, params, err := mime.ParseMediaType(hdr.Get("Content-Type"))
+	if err != nil {
+		return false
+	}
+
+	return strings.HasPrefix(mediatype, "multipart/") && params["boundary"] != "" || isEncapsulatedMessage(mediatype)
+}
+
+// isEncapsulatedMessage checks that entity is encapsulated message,
+// including RFC6532 internationalized one
+func isEncapsulatedMessage(mediatype string) bool {
+	return mediatype == "message/rfc822" || mediatype == "message/global"
+}
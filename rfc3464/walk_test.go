@@ -0,0 +1,157 @@
+package rfc3464
+
+import (
+	"errors"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testNestedReport = `Content-Type: multipart/report; report-type=delivery-status; boundary="REPORT"
+
+--REPORT
+Content-Type: text/plain
+
+Delivery failed.
+
+--REPORT
+Content-Type: message/delivery-status
+
+Reporting-MTA: dns; mx.example.com
+
+Final-Recipient: rfc822; user@example.com
+Action: failed
+Status: 5.1.1
+
+--REPORT--
+`
+
+func Test_Parse_NestedMultipart(t *testing.T) {
+	value := `From: security-gateway@example.com
+MIME-Version: 1.0
+Content-Type: multipart/mixed; boundary="MIXED"
+
+--MIXED
+Content-Type: text/plain
+
+This message has been scanned.
+
+--MIXED
+` + testNestedReport + `
+--MIXED--
+`
+
+	msg, _ := mail.ReadMessage(strings.NewReader(value))
+	dsn, err := Parse(msg)
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, "mx.example.com", dsn.ReportingMTA.Value)
+		assert.Len(t, dsn.Recipients, 1)
+		assert.Equal(t, "2", dsn.Path.String())
+	}
+}
+
+func Test_Parse_ForwardedMessage(t *testing.T) {
+	value := `From: user@example.com
+Subject: Fwd: Undelivered Mail Returned to Sender
+MIME-Version: 1.0
+Content-Type: multipart/mixed; boundary="MIXED"
+
+--MIXED
+Content-Type: text/plain
+
+See the bounce below.
+
+--MIXED
+Content-Type: message/rfc822
+
+From: MAILER-DAEMON@example.com
+Subject: Undelivered Mail Returned to Sender
+MIME-Version: 1.0
+Content-Type: multipart/mixed; boundary="INNER"
+
+--INNER
+Content-Type: text/plain
+
+Wrapped.
+
+--INNER
+` + testNestedReport + `
+--INNER--
+
+--MIXED--
+`
+
+	msg, _ := mail.ReadMessage(strings.NewReader(value))
+	dsn, err := Parse(msg)
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, "user@example.com", dsn.Recipients[0].FinalRecipient.Value)
+		assert.Equal(t, PartPath{2, 2}, dsn.Path)
+		assert.Equal(t, "2.2", dsn.Path.String())
+	}
+
+	msg, _ = mail.ReadMessage(strings.NewReader(value))
+	_, err = ParseWithOptions(msg, ParseOptions{MaxDepth: 3})
+
+	assert.True(t, errors.Is(err, ErrorLimitExceeded))
+	assert.EqualError(t, err, "rfc3464: part 2: Limit exceeded: MaxDepth 3")
+
+	msg, _ = mail.ReadMessage(strings.NewReader(value))
+	_, err = ParseWithOptions(msg, ParseOptions{MaxDepth: 4})
+
+	assert.NoError(t, err)
+}
+
+func Test_Parse_TopLevelPath(t *testing.T) {
+	msg, _ := mail.ReadMessage(strings.NewReader(testNestedReport))
+	dsn, err := Parse(msg)
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, "", dsn.Path.String())
+	}
+}
+
+func Test_Parse_NestedNotFound(t *testing.T) {
+	value := `From: user@example.com
+MIME-Version: 1.0
+Content-Type: multipart/mixed; boundary="MIXED"
+
+--MIXED
+Content-Type: text/plain
+
+Nothing here.
+
+--MIXED--
+`
+
+	msg, _ := mail.ReadMessage(strings.NewReader(value))
+	_, err := Parse(msg)
+
+	assert.True(t, errors.Is(err, ErrorDSNPartNotFound))
+}
+
+func Test_Parse_ForwardedGlobalMessage(t *testing.T) {
+	value := `From: user@example.com
+MIME-Version: 1.0
+Content-Type: multipart/mixed; boundary="MIXED"
+
+--MIXED
+Content-Type: message/global
+
+From: MAILER-DAEMON@example.com
+MIME-Version: 1.0
+` + testNestedReport + `
+--MIXED--
+`
+
+	msg, _ := mail.ReadMessage(strings.NewReader(value))
+	dsn, err := Parse(msg)
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, "1", dsn.Path.String())
+		assert.Len(t, dsn.Recipients, 1)
+	}
+}
@@ -0,0 +1,117 @@
+package rfc3464
+
+import (
+	"strings"
+)
+
+/*
+DecodeXtext decodes RFC3461 xtext encoded value
+
+	xtext = *( xchar / hexchar )
+
+	xchar = any ASCII CHAR between "!" (33) and "~" (126) inclusive,
+		except for "+" and "=".
+
+	; "hexchar"s are intended to encode octets that cannot appear
+	; as ASCII characters within an esmtp-value.
+
+	hexchar = ASCII "+" immediately followed by two upper case
+		hexadecimal digits
+*/
+func DecodeXtext(value string) (string, error) {
+	if !strings.Contains(value, "+") {
+		return value, nil
+	}
+
+	b := make([]byte, 0, len(value))
+
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+
+		if c != '+' {
+			b = append(b, c)
+			continue
+		}
+
+		if i+2 >= len(value) {
+			return "", ErrorInvalidAddressEncoding
+		}
+
+		hi, ok1 := unhex(value[i+1])
+		lo, ok2 := unhex(value[i+2])
+		if !ok1 || !ok2 {
+			return "", ErrorInvalidAddressEncoding
+		}
+
+		b = append(b, hi<<4|lo)
+		i += 2
+	}
+
+	return string(b), nil
+}
+
+// EncodeXtext encodes value as RFC3461 xtext
+func EncodeXtext(value string) string {
+	const hex = "0123456789ABCDEF"
+
+	var b strings.Builder
+
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+
+		if c >= '!' && c <= '~' && c != '+' && c != '=' {
+			b.WriteByte(c)
+			continue
+		}
+
+		b.WriteByte('+')
+		b.WriteByte(hex[c>>4])
+		b.WriteByte(hex[c&0x0f])
+	}
+
+	return b.String()
+}
+
+// escapeXtext encodes bytes of value which are not xchar, like EncodeXtext,
+// but keeps valid hexchar, so xtext value is not encoded twice
+func escapeXtext(value string) string {
+	const hex = "0123456789ABCDEF"
+
+	var b strings.Builder
+
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+
+		if c >= '!' && c <= '~' && c != '+' && c != '=' || c == '+' && isHexchar(value[i+1:]) {
+			b.WriteByte(c)
+			continue
+		}
+
+		b.WriteByte('+')
+		b.WriteByte(hex[c>>4])
+		b.WriteByte(hex[c&0x0f])
+	}
+
+	return b.String()
+}
+
+// isHexchar reports whether s starts with two upper case hexadecimal digits
+func isHexchar(s string) bool {
+	return len(s) >= 2 && isUpperHex(s[0]) && isUpperHex(s[1])
+}
+
+func isUpperHex(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'A' && c <= 'F'
+}
+
+func unhex(c byte) (byte, bool) {
+	switch {
+	case c >= '0' && c <= '9':
+		return c - '0', true
+	case c >= 'A' && c <= 'F':
+		return c - 'A' + 10, true
+	case c >= 'a' && c <= 'f':
+		return c - 'a' + 10, true
+	}
+	return 0, false
+}
@@ -0,0 +1,49 @@
+package rfc3464
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DecodeXtext(t *testing.T) {
+	type fixture struct {
+		value         string
+		expected      string
+		expectedError error
+	}
+
+	fixtures := []fixture{
+		fixture{value: "user@example.com", expected: "user@example.com"},
+		fixture{value: "user+2Btag@example.com", expected: "user+tag@example.com"},
+		fixture{value: "a+3Db+20c", expected: "a=b c"},
+		fixture{value: "lower+2bcase", expected: "lower+case"},
+		fixture{value: "user+tag@example.com", expectedError: ErrorInvalidAddressEncoding},
+		fixture{value: "trailing+2", expectedError: ErrorInvalidAddressEncoding},
+	}
+
+	for _, f := range fixtures {
+		got, err := DecodeXtext(f.value)
+
+		assert.Equal(t, f.expectedError, err, "Fixture: %q", f.value)
+		assert.Equal(t, f.expected, got, "Fixture: %q", f.value)
+	}
+}
+
+func Test_EncodeXtext(t *testing.T) {
+	fixtures := map[string]string{
+		"user@example.com":     "user@example.com",
+		"user+tag@example.com": "user+2Btag@example.com",
+		"a=b c":                "a+3Db+20c",
+		"я":                    "+D1+8F",
+	}
+
+	for value, expected := range fixtures {
+		got := EncodeXtext(value)
+		assert.Equal(t, expected, got, "Value: %q", value)
+
+		decoded, err := DecodeXtext(got)
+		assert.NoError(t, err)
+		assert.Equal(t, value, decoded)
+	}
+}
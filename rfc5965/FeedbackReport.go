@@ -0,0 +1,262 @@
+package rfc5965
+
+import (
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/YouDoCom/go-maildsnparsers/dsnerrors"
+	"github.com/YouDoCom/go-maildsnparsers/internal/rfc822"
+	"github.com/YouDoCom/go-maildsnparsers/rfc3464"
+)
+
+/*
+FeedbackReport is RFC5965 feedback report, machine-readable
+message/feedback-report part of multipart/report
+
+	feedback-report =
+		feedback-type
+		user-agent
+		version
+		[ original-envelope-id ]
+		[ original-mail-from ]
+		[ arrival-date ]
+		[ reporting-mta ]
+		[ source-ip ]
+		[ incidents ]
+		*( authentication-results )
+		*( original-rcpt-to )
+		*( reported-domain )
+		*( reported-uri )
+		*( ext-field )
+*/
+type FeedbackReport struct {
+	/*
+		Feedback-Type:  Contains the type of feedback report (as defined in
+		the corresponding IANA registry and later in this memo).  This is
+		intended to let report parsers distinguish among different types
+		of reports.
+	*/
+	FeedbackType FeedbackType
+
+	/*
+		User-Agent:  Indicates the name and version of the software program
+		that generated the report.  The format of this field MUST follow
+		section 14.43 of [HTTP].
+	*/
+	UserAgent string
+
+	/*
+		Version:  Indicates the version of specification that the report
+		generator is using to generate the report.  The version number in
+		this specification is set to "1".
+	*/
+	Version string
+
+	/*
+		Original-Envelope-Id:  Envelope ID string used in the original SMTP
+		transaction (see section 2.2.1 of [DSN]).
+	*/
+	OriginalEnvelopeID string
+
+	/*
+		Original-Mail-From:  Email address used in the MAIL FROM portion of
+		the original SMTP transaction.
+	*/
+	OriginalMailFrom string
+
+	/*
+		Arrival-Date:  Indicates the date and time at which the original
+		message was received by the Mail Transfer Agent (MTA) of the
+		report generating entity.
+	*/
+	ArrivalDate string
+
+	/*
+		Reporting-MTA:  Indicates the name of the MTA generating this
+		feedback report.  This field is defined in section 2.2.2 of [DSN]
+		except that it is optional here.
+	*/
+	ReportingMTA rfc3464.TypeValueField
+
+	/*
+		Source-IP:  The IPv4 or IPv6 source address of the apparent source
+		of the abusive message.
+	*/
+	SourceIP string
+
+	/*
+		Incidents:  A positive integer indicating the number of incidents
+		represented by the report.
+	*/
+	// Zero value means that field is absent, i.e. report represents single incident.
+	Incidents int
+
+	/*
+		Authentication-Results:  Indicates the result of one or more
+		authentication checks run by the report generator.
+	*/
+	AuthenticationResults []string
+
+	/*
+		Original-Rcpt-To:  Email address used in the RCPT TO portion of the
+		original SMTP transaction.  This field MAY be repeated.
+	*/
+	OriginalRcptTo []string
+
+	/*
+		Reported-Domain:  A domain name that the report generator believes to
+		be relevant to the report, e.g., the domain whose reputation is
+		affected by the message.
+	*/
+	ReportedDomain []string
+
+	/*
+		Reported-URI:  A URI that the report generator believes to be
+		relevant to the report, e.g., a URI found in the message.
+	*/
+	ReportedURI []string
+
+	// Extensions contains fields not defined by RFC5965,
+	// e.g. RFC6591 authentication failure fields
+	Extensions rfc3464.Extensions
+
+	// Fields lists fields as written in report, in original order
+	Fields rfc3464.Fields
+
+	// ReportType is lower-cased report-type parameter of multipart/report
+	ReportType string
+
+	// Path is location of multipart/report entity in parsed message
+	Path rfc3464.PartPath
+
+	// HumanReadable is decoded UTF-8 text of the first part of report
+	HumanReadable string
+
+	// ReturnedMessage is reported message or its header returned
+	// in the third part. It is nil if report has no such part.
+	ReturnedMessage *rfc3464.ReturnedMessage
+}
+
+// ArrivalTime returns parsed Arrival-Date field
+func (r *FeedbackReport) ArrivalTime() (time.Time, error) {
+	if strings.TrimSpace(r.ArrivalDate) == "" {
+		return time.Time{}, ErrorFieldNotPresent
+	}
+	return rfc3464.ParseDateTime(r.ArrivalDate)
+}
+
+// SourceAddr returns parsed Source-IP field
+func (r *FeedbackReport) SourceAddr() (net.IP, error) {
+	value := strings.TrimSpace(r.SourceIP)
+	if value == "" {
+		return nil, ErrorFieldNotPresent
+	}
+
+	ip := net.ParseIP(strings.TrimSuffix(strings.TrimPrefix(value, "["), "]"))
+	if ip == nil {
+		return nil, ErrorInvalidSourceIP
+	}
+	return ip, nil
+}
+
+// Sender returns Original-Mail-From address without angle brackets
+func (r *FeedbackReport) Sender() string {
+	return trimAngleBrackets(r.OriginalMailFrom)
+}
+
+// Recipients returns Original-Rcpt-To addresses without angle brackets
+func (r *FeedbackReport) Recipients() []string {
+	var recipients []string
+	for _, rcpt := range r.OriginalRcptTo {
+		recipients = append(recipients, trimAngleBrackets(rcpt))
+	}
+	return recipients
+}
+
+func trimAngleBrackets(value string) string {
+	value = strings.TrimSpace(value)
+	return strings.TrimSuffix(strings.TrimPrefix(value, "<"), ">")
+}
+
+// fillFromFields fills report from fields of feedback report.
+// dsnerrors.MissingFieldError is returned when required field is absent.
+func (r *FeedbackReport) fillFromFields(fields rfc3464.Fields) error {
+	r.Fields = fields
+	r.Extensions = make(rfc3464.Extensions)
+
+	var (
+		keyFeedbackType          = textproto.CanonicalMIMEHeaderKey("Feedback-Type")
+		keyUserAgent             = textproto.CanonicalMIMEHeaderKey("User-Agent")
+		keyVersion               = textproto.CanonicalMIMEHeaderKey("Version")
+		keyOriginalEnvelopeID    = textproto.CanonicalMIMEHeaderKey("Original-Envelope-Id")
+		keyOriginalMailFrom      = textproto.CanonicalMIMEHeaderKey("Original-Mail-From")
+		keyArrivalDate           = textproto.CanonicalMIMEHeaderKey("Arrival-Date")
+		keyReceivedDate          = textproto.CanonicalMIMEHeaderKey("Received-Date")
+		keyReportingMTA          = textproto.CanonicalMIMEHeaderKey("Reporting-MTA")
+		keySourceIP              = textproto.CanonicalMIMEHeaderKey("Source-IP")
+		keyIncidents             = textproto.CanonicalMIMEHeaderKey("Incidents")
+		keyAuthenticationResults = textproto.CanonicalMIMEHeaderKey("Authentication-Results")
+		keyOriginalRcptTo        = textproto.CanonicalMIMEHeaderKey("Original-Rcpt-To")
+		keyReportedDomain        = textproto.CanonicalMIMEHeaderKey("Reported-Domain")
+		keyReportedURI           = textproto.CanonicalMIMEHeaderKey("Reported-URI")
+	)
+
+	var err error
+
+	for k, v := range fields.Header() {
+		val := strings.Join(v, "\n")
+
+		switch k {
+		case keyFeedbackType:
+			r.FeedbackType = ParseFeedbackType(v[0])
+		case keyUserAgent:
+			r.UserAgent = val
+		case keyVersion:
+			r.Version = rfc822.StripComments(v[0])
+		case keyOriginalEnvelopeID:
+			r.OriginalEnvelopeID = val
+		case keyOriginalMailFrom:
+			r.OriginalMailFrom = val
+		case keyArrivalDate, keyReceivedDate:
+			// Received-Date is used by obsolete ARF drafts
+			if r.ArrivalDate == "" || k == keyArrivalDate {
+				r.ArrivalDate = v[0]
+			}
+		case keyReportingMTA:
+			r.ReportingMTA = rfc3464.ParseTypeValueField(rfc822.StripComments(val))
+		case keySourceIP:
+			r.SourceIP = rfc822.StripComments(v[0])
+		case keyIncidents:
+			r.Incidents, err = parseIncidents(v[0])
+		case keyAuthenticationResults:
+			r.AuthenticationResults = v
+		case keyOriginalRcptTo:
+			r.OriginalRcptTo = v
+		case keyReportedDomain:
+			r.ReportedDomain = v
+		case keyReportedURI:
+			r.ReportedURI = v
+		default:
+			r.Extensions.Set(k, val)
+		}
+	}
+
+	for _, name := range []string{"Feedback-Type", "User-Agent", "Version"} {
+		if fields.Count(name) == 0 {
+			return &dsnerrors.MissingFieldError{Field: name}
+		}
+	}
+
+	return err
+}
+
+func parseIncidents(value string) (int, error) {
+	n, err := strconv.Atoi(rfc822.StripComments(value))
+	if err != nil || n <= 0 {
+		return 0, ErrorInvalidIncidents
+	}
+	return n, nil
+}
@@ -0,0 +1,76 @@
+package rfc5965
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FeedbackReport_ArrivalTime(t *testing.T) {
+	r := &FeedbackReport{ArrivalDate: "Thu, 8 Mar 2005 14:00:00 EDT"}
+
+	got, err := r.ArrivalTime()
+	if assert.NoError(t, err) {
+		assert.True(t, time.Date(2005, 3, 8, 18, 0, 0, 0, time.UTC).Equal(got))
+	}
+
+	_, err = (&FeedbackReport{}).ArrivalTime()
+	assert.Equal(t, ErrorFieldNotPresent, err)
+}
+
+func Test_FeedbackReport_SourceAddr(t *testing.T) {
+	type fixture struct {
+		value    string
+		expected net.IP
+		err      error
+	}
+
+	fixtures := []fixture{
+		fixture{value: "192.0.2.1", expected: net.ParseIP("192.0.2.1")},
+		fixture{value: "[2001:db8::1]", expected: net.ParseIP("2001:db8::1")},
+		fixture{value: "mx.example.com", err: ErrorInvalidSourceIP},
+		fixture{value: " ", err: ErrorFieldNotPresent},
+	}
+
+	for _, f := range fixtures {
+		ip, err := (&FeedbackReport{SourceIP: f.value}).SourceAddr()
+
+		assert.Equal(t, f.err, err, "Fixture: %q", f.value)
+		assert.Equal(t, f.expected, ip, "Fixture: %q", f.value)
+	}
+}
+
+func Test_FeedbackReport_Addresses(t *testing.T) {
+	r := &FeedbackReport{
+		OriginalMailFrom: "<somespammer@example.net>",
+		OriginalRcptTo:   []string{"<user@example.com>", "other@example.com"},
+	}
+
+	assert.Equal(t, "somespammer@example.net", r.Sender())
+	assert.Equal(t, []string{"user@example.com", "other@example.com"}, r.Recipients())
+	assert.Nil(t, (&FeedbackReport{}).Recipients())
+}
+
+func Test_parseIncidents(t *testing.T) {
+	type fixture struct {
+		value    string
+		expected int
+		err      error
+	}
+
+	fixtures := []fixture{
+		fixture{value: "3", expected: 3},
+		fixture{value: " 12 (last hour) ", expected: 12},
+		fixture{value: "0", err: ErrorInvalidIncidents},
+		fixture{value: "many", err: ErrorInvalidIncidents},
+	}
+
+	for _, f := range fixtures {
+		n, err := parseIncidents(f.value)
+
+		assert.Equal(t, f.err, err, "Fixture: %q", f.value)
+		assert.Equal(t, f.expected, n, "Fixture: %q", f.value)
+	}
+}
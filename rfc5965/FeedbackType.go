@@ -0,0 +1,50 @@
+package rfc5965
+
+import (
+	"strings"
+
+	"github.com/YouDoCom/go-maildsnparsers/rfc3464"
+)
+
+/*
+FeedbackType is value of Feedback-Type field
+
+	abuse:  indicates unsolicited email or some other kind of email
+		abuse.
+
+	fraud:  indicates some kind of fraud or phishing activity.
+
+	other:  any other feedback that doesn't fit into other registered
+		types.
+
+	virus:  report of a virus found in the originating message.
+
+Types registered later: "not-spam" (RFC6650) and "auth-failure" (RFC6591).
+*/
+type FeedbackType string
+
+// Registered feedback types
+const (
+	FeedbackAbuse       FeedbackType = "abuse"
+	FeedbackFraud       FeedbackType = "fraud"
+	FeedbackOther       FeedbackType = "other"
+	FeedbackVirus       FeedbackType = "virus"
+	FeedbackNotSpam     FeedbackType = "not-spam"
+	FeedbackAuthFailure FeedbackType = "auth-failure"
+)
+
+// ParseFeedbackType returns lower-cased value of Feedback-Type field
+// without comments
+func ParseFeedbackType(value string) FeedbackType {
+	value, _ = rfc3464.SplitComments(value)
+	return FeedbackType(strings.ToLower(value))
+}
+
+// IsKnown checks that feedback type is one of registered types
+func (t FeedbackType) IsKnown() bool {
+	switch FeedbackType(strings.ToLower(strings.TrimSpace(string(t)))) {
+	case FeedbackAbuse, FeedbackFraud, FeedbackOther, FeedbackVirus, FeedbackNotSpam, FeedbackAuthFailure:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,33 @@
+package rfc5965
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseFeedbackType(t *testing.T) {
+	type fixture struct {
+		value    string
+		expected FeedbackType
+		known    bool
+	}
+
+	fixtures := []fixture{
+		fixture{value: "abuse", expected: FeedbackAbuse, known: true},
+		fixture{value: " Fraud ", expected: FeedbackFraud, known: true},
+		fixture{value: "auth-failure (DMARC)", expected: FeedbackAuthFailure, known: true},
+		fixture{value: "not-spam", expected: FeedbackNotSpam, known: true},
+		fixture{value: "opt-out", expected: FeedbackType("opt-out"), known: false},
+		fixture{value: "", expected: FeedbackType(""), known: false},
+	}
+
+	for _, f := range fixtures {
+		got := ParseFeedbackType(f.value)
+
+		assert.Equal(t, f.expected, got, "Fixture: %q", f.value)
+		assert.Equal(t, f.known, got.IsKnown(), "Fixture: %q", f.value)
+	}
+
+	assert.True(t, FeedbackType("VIRUS").IsKnown())
+}
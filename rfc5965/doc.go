@@ -0,0 +1,13 @@
+// Package rfc5965 abuse feedback reports parser
+//
+// "An Extensible Format for Email Feedback Reports"
+//
+// https://tools.ietf.org/html/rfc5965
+//
+// Feedback reports (Abuse Reporting Format, ARF) are sent by mailbox
+// providers' feedback loops when their users mark messages as spam.
+// They share multipart/report envelope with delivery status notifications,
+// so parsing is built on multipart/report handling of package rfc3464.
+// Importing this package registers "feedback-report" handler
+// for rfc3464.ParseReport.
+package rfc5965
@@ -0,0 +1,45 @@
+package rfc5965
+
+import (
+	"errors"
+
+	"github.com/YouDoCom/go-maildsnparsers/dsnerrors"
+	"github.com/YouDoCom/go-maildsnparsers/rfc3464"
+)
+
+// parserName is used as ParseError.Parser
+const parserName = "rfc5965"
+
+var (
+	// ErrorNilMessage returned when message is nil
+	ErrorNilMessage = dsnerrors.ErrorNilMessage
+
+	// ErrorDSNNotFound matches every error returned when message is not a report
+	ErrorDSNNotFound = dsnerrors.ErrorDSNNotFound
+
+	// ErrorFeedbackReportNotFound returned when message does not contain feedback report
+	ErrorFeedbackReportNotFound = dsnerrors.Derive(ErrorDSNNotFound, "Feedback report not found in message")
+
+	// ErrorMalformedField returned when line of feedback report is not valid field
+	ErrorMalformedField = dsnerrors.ErrorMalformedField
+
+	// ErrorLimitExceeded matches LimitError returned when message exceeds ParseOptions limits
+	ErrorLimitExceeded = dsnerrors.ErrorLimitExceeded
+
+	// ErrorFieldNotPresent returned when requested field is absent or empty
+	ErrorFieldNotPresent = rfc3464.ErrorFieldNotPresent
+
+	// ErrorRequiredFieldMissing matches dsnerrors.MissingFieldError returned
+	// when Feedback-Type, User-Agent or Version field is absent
+	ErrorRequiredFieldMissing = dsnerrors.ErrorRequiredFieldMissing
+
+	// ErrorUnexpectedReportValue returned when handler registered for
+	// feedback-report report-type does not return *FeedbackReport
+	ErrorUnexpectedReportValue = dsnerrors.Derive(rfc3464.ErrorUnexpectedReportValue, "Report value is not feedback report")
+
+	// ErrorInvalidIncidents returned when Incidents field is not positive integer
+	ErrorInvalidIncidents = errors.New("Invalid incidents count")
+
+	// ErrorInvalidSourceIP returned when Source-IP field is not valid IP address
+	ErrorInvalidSourceIP = errors.New("Invalid source IP address")
+)
@@ -0,0 +1,115 @@
+package rfc5965
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/mail"
+
+	"github.com/YouDoCom/go-maildsnparsers/dsnerrors"
+	"github.com/YouDoCom/go-maildsnparsers/internal/fieldblock"
+	"github.com/YouDoCom/go-maildsnparsers/internal/message"
+	"github.com/YouDoCom/go-maildsnparsers/rfc3464"
+)
+
+// reportType is report-type parameter of feedback reports
+const reportType = "feedback-report"
+
+var feedbackReportHandler = rfc3464.ReportHandler{
+	MediaTypes: []string{"message/feedback-report"},
+	Parse: func(mediatype string, body io.Reader, opts rfc3464.ParseOptions) (interface{}, error) {
+		report, err := parseFeedbackReport(body, opts)
+		if report != nil {
+			return report, err
+		}
+		return nil, err
+	},
+}
+
+func init() {
+	rfc3464.RegisterReportHandler(reportType, feedbackReportHandler)
+}
+
+// Parse parses RFC5965 feedback report from mail message with default options
+func Parse(message *mail.Message) (*FeedbackReport, error) {
+	return ParseWithOptions(message, rfc3464.ParseOptions{})
+}
+
+// ParseReader parses RFC5965 feedback report from raw message.
+// See rfc3464.ParseReader.
+func ParseReader(ctx context.Context, r io.Reader, opts rfc3464.ParseOptions) (*FeedbackReport, error) {
+	msg, err := message.Read(ctx, r, message.HeaderLimit(opts.MaxHeaderBytes))
+	if err != nil {
+		return nil, dsnerrors.Wrap(parserName, "", 0, err)
+	}
+
+	return ParseWithOptions(msg, opts)
+}
+
+// ParseBytes parses RFC5965 feedback report from raw message. See ParseReader.
+func ParseBytes(ctx context.Context, data []byte, opts rfc3464.ParseOptions) (*FeedbackReport, error) {
+	return ParseReader(ctx, bytes.NewReader(data), opts)
+}
+
+// ParseWithOptions parses RFC5965 feedback report from mail message.
+//
+// The multipart/report entity with "feedback-report" report-type is searched
+// the same way as by rfc3464.ParseWithOptions, FeedbackReport.Path contains
+// its location. Reported message is kept in FeedbackReport.ReturnedMessage
+// according to opts.ReturnedMessage.
+// ErrorFeedbackReportNotFound is returned when message does not contain
+// feedback report, including delivery status notifications and other reports.
+// dsnerrors.MissingFieldError is returned along with FeedbackReport when
+// Feedback-Type, User-Agent or Version is absent.
+func ParseWithOptions(message *mail.Message, opts rfc3464.ParseOptions) (*FeedbackReport, error) {
+	report, err := rfc3464.FindReport(message, opts, reportType)
+
+	var feedback *FeedbackReport
+	if report != nil {
+		if feedback, _ = report.Value.(*FeedbackReport); feedback != nil {
+			feedback.ReportType = report.ReportType
+			feedback.Path = report.Path
+			feedback.HumanReadable = report.HumanReadable
+			feedback.ReturnedMessage = report.ReturnedMessage
+		}
+	}
+
+	switch {
+	case err == nil && feedback == nil:
+		return nil, dsnerrors.Wrap(parserName, report.Path.String(), 0, ErrorUnexpectedReportValue)
+	case err == nil:
+		return feedback, nil
+	case errors.Is(err, ErrorNilMessage):
+		return nil, dsnerrors.Wrap(parserName, "", 0, ErrorNilMessage)
+	case errors.Is(err, ErrorDSNNotFound):
+		return nil, dsnerrors.Wrap(parserName, "", 0, ErrorFeedbackReportNotFound)
+	}
+
+	return feedback, dsnerrors.WithParser(parserName, err)
+}
+
+// IsFeedbackReport checks that message is RFC5965 feedback report:
+// multipart/report with "feedback-report" report-type.
+//
+// See rfc3464.DetectReportType.
+func IsFeedbackReport(message *mail.Message) bool {
+	t, ok := rfc3464.DetectReportType(message)
+	return ok && t == reportType
+}
+
+// parseFeedbackReport parses fields of message/feedback-report part
+func parseFeedbackReport(body io.Reader, opts rfc3464.ParseOptions) (*FeedbackReport, error) {
+	fields, err := fieldblock.ReadFields(body, message.HeaderLimit(opts.MaxHeaderBytes))
+	if err != nil && len(fields) == 0 {
+		return nil, err
+	}
+
+	// report is filled from fields preceding malformed line too
+	report := &FeedbackReport{}
+	if fillErr := report.fillFromFields(fields); err == nil {
+		err = fillErr
+	}
+
+	return report, err
+}
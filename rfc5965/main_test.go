@@ -0,0 +1,252 @@
+package rfc5965
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"github.com/YouDoCom/go-maildsnparsers/dsnerrors"
+	"github.com/YouDoCom/go-maildsnparsers/rfc3464"
+	"github.com/stretchr/testify/assert"
+)
+
+const testFeedbackMessage = `From: <abusedesk@example.com>
+Date: Thu, 8 Mar 2005 17:40:36 EDT
+Subject: FW: Earn money
+To: <abuse@example.net>
+MIME-Version: 1.0
+Content-Type: multipart/report; report-type=feedback-report;
+     boundary="part1_13d.2e68ed54_boundary"
+
+--part1_13d.2e68ed54_boundary
+Content-Type: text/plain; charset="US-ASCII"
+Content-Transfer-Encoding: 7bit
+
+This is an email abuse report for an email message received from IP
+192.0.2.1 on Thu, 8 Mar 2005 14:00:00 EDT.  For more information
+about this format please see http://www.mipassoc.org/arf/.
+
+--part1_13d.2e68ed54_boundary
+Content-Type: message/feedback-report
+
+Feedback-Type: abuse
+User-Agent: SomeGenerator/1.0
+Version: 1
+Original-Mail-From: <somespammer@example.net>
+Original-Rcpt-To: <user@example.com>
+Arrival-Date: Thu, 8 Mar 2005 14:00:00 EDT
+Reporting-MTA: dns; mail.example.com
+Source-IP: 192.0.2.1
+Authentication-Results: mail.example.com;
+               spf=fail smtp.mail=somespammer@example.com
+Reported-Domain: example.net
+Reported-Uri: http://example.net/earn_money.html
+Reported-Uri: mailto:user@example.com
+Removal-Recipient: user@example.com
+
+--part1_13d.2e68ed54_boundary
+Content-Type: message/rfc822
+Content-Disposition: inline
+
+From: <somespammer@example.net>
+Received: from mailserver.example.net (mailserver.example.net
+        [192.0.2.1]) by example.com with ESMTP id M63d4137594e46;
+        Thu, 08 Mar 2005 14:00:00 -0400
+To: <Undisclosed Recipients>
+Subject: Earn money
+MIME-Version: 1.0
+Content-type: text/plain
+Message-ID: 8787KJKJ3K4J3K4J3K4J3.mail@example.net
+Date: Thu, 02 Sep 2004 12:31:03 -0500
+
+Spam Spam Spam
+Spam Spam Spam
+Spam Spam Spam
+Spam Spam Spam
+--part1_13d.2e68ed54_boundary--
+`
+
+const testDSNMessage = `Content-Type: multipart/report; report-type=delivery-status; boundary="DSN"
+
+--DSN
+Content-Type: text/plain
+
+Delivery failed.
+
+--DSN
+Content-Type: message/delivery-status
+
+Reporting-MTA: dns; mx.example.com
+
+Final-Recipient: rfc822; user@example.com
+Action: failed
+Status: 5.1.1
+
+--DSN--
+`
+
+func Test_Parse(t *testing.T) {
+	msg, _ := mail.ReadMessage(strings.NewReader(testFeedbackMessage))
+	report, err := ParseWithOptions(msg, rfc3464.ParseOptions{ReturnedMessage: rfc3464.ReturnedMessageFull})
+
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, FeedbackAbuse, report.FeedbackType)
+	assert.Equal(t, "SomeGenerator/1.0", report.UserAgent)
+	assert.Equal(t, "1", report.Version)
+	assert.Equal(t, "somespammer@example.net", report.Sender())
+	assert.Equal(t, []string{"user@example.com"}, report.Recipients())
+	assert.Equal(t, "Thu, 8 Mar 2005 14:00:00 EDT", report.ArrivalDate)
+	assert.Equal(t, rfc3464.TypeValueField{Type: "dns", Value: "mail.example.com"}, report.ReportingMTA)
+	assert.Equal(t, "192.0.2.1", report.SourceIP)
+	assert.Equal(t, 0, report.Incidents)
+	assert.Equal(t, []string{"mail.example.com; spf=fail smtp.mail=somespammer@example.com"}, report.AuthenticationResults)
+	assert.Equal(t, []string{"example.net"}, report.ReportedDomain)
+	assert.Equal(t, []string{"http://example.net/earn_money.html", "mailto:user@example.com"}, report.ReportedURI)
+	assert.Equal(t, "user@example.com", report.Extensions.Get("Removal-Recipient"))
+	assert.Equal(t, 13, len(report.Fields))
+
+	assert.Equal(t, "feedback-report", report.ReportType)
+	assert.Contains(t, report.HumanReadable, "This is an email abuse report")
+
+	if assert.NotNil(t, report.ReturnedMessage) {
+		assert.Equal(t, "message/rfc822", report.ReturnedMessage.ContentType)
+		assert.Equal(t, "Earn money", report.ReturnedMessage.Header.Get("Subject"))
+
+		body, _ := ioutil.ReadAll(report.ReturnedMessage.Body)
+		assert.Contains(t, string(body), "Spam Spam Spam")
+	}
+}
+
+func Test_Parse_NotFound(t *testing.T) {
+	fixtures := []string{
+		testDSNMessage,
+		"Content-Type: text/plain\n\nHello\n",
+	}
+
+	for _, f := range fixtures {
+		msg, _ := mail.ReadMessage(strings.NewReader(f))
+		report, err := Parse(msg)
+
+		assert.Nil(t, report)
+		assert.True(t, errors.Is(err, ErrorFeedbackReportNotFound), "Fixture: %q", f)
+		assert.True(t, errors.Is(err, ErrorDSNNotFound), "Fixture: %q", f)
+		assert.EqualError(t, err, "rfc5965: Feedback report not found in message")
+	}
+
+	_, err := Parse(nil)
+	assert.True(t, errors.Is(err, ErrorNilMessage))
+}
+
+func Test_Parse_InvalidIncidents(t *testing.T) {
+	data := strings.Replace(testFeedbackMessage, "Source-IP: 192.0.2.1\n", "Source-IP: 192.0.2.1\nIncidents: none\n", 1)
+
+	msg, _ := mail.ReadMessage(strings.NewReader(data))
+	report, err := Parse(msg)
+
+	assert.True(t, errors.Is(err, ErrorInvalidIncidents))
+
+	var parseErr *dsnerrors.ParseError
+	if assert.True(t, errors.As(err, &parseErr)) {
+		assert.Equal(t, "rfc5965", parseErr.Parser)
+		assert.Equal(t, "2", parseErr.Part)
+	}
+
+	if assert.NotNil(t, report) {
+		assert.Equal(t, FeedbackAbuse, report.FeedbackType)
+	}
+}
+
+func Test_Parse_RequiredFieldMissing(t *testing.T) {
+	type fixture struct {
+		field         string
+		expectedError string
+	}
+
+	fixtures := []fixture{
+		fixture{
+			field:         "Feedback-Type: abuse\n",
+			expectedError: "rfc5965: part 2: Required field missing: Feedback-Type",
+		},
+		fixture{
+			field:         "User-Agent: SomeGenerator/1.0\n",
+			expectedError: "rfc5965: part 2: Required field missing: User-Agent",
+		},
+		fixture{
+			field:         "Version: 1\n",
+			expectedError: "rfc5965: part 2: Required field missing: Version",
+		},
+	}
+
+	for _, f := range fixtures {
+		data := strings.Replace(testFeedbackMessage, f.field, "", 1)
+
+		msg, _ := mail.ReadMessage(strings.NewReader(data))
+		report, err := Parse(msg)
+
+		assert.True(t, errors.Is(err, ErrorRequiredFieldMissing), f.field)
+		assert.EqualError(t, err, f.expectedError, f.field)
+		if assert.NotNil(t, report, f.field) {
+			assert.Equal(t, "192.0.2.1", report.SourceIP, f.field)
+		}
+	}
+}
+
+func Test_Parse_UnexpectedReportValue(t *testing.T) {
+	rfc3464.RegisterReportHandler(reportType, rfc3464.ReportHandler{
+		MediaTypes: []string{"message/feedback-report"},
+		Parse: func(mediatype string, body io.Reader, opts rfc3464.ParseOptions) (interface{}, error) {
+			return "not a feedback report", nil
+		},
+	})
+	defer rfc3464.RegisterReportHandler(reportType, feedbackReportHandler)
+
+	msg, _ := mail.ReadMessage(strings.NewReader(testFeedbackMessage))
+	report, err := Parse(msg)
+
+	assert.Nil(t, report)
+	assert.True(t, errors.Is(err, ErrorUnexpectedReportValue))
+	assert.EqualError(t, err, "rfc5965: Report value is not feedback report")
+}
+
+func Test_ParseBytes(t *testing.T) {
+	data := strings.Replace(testFeedbackMessage, "\n", "\r\n", -1)
+	report, err := ParseBytes(context.Background(), []byte(data), rfc3464.ParseOptions{})
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, FeedbackAbuse, report.FeedbackType)
+		if assert.NotNil(t, report.ReturnedMessage) {
+			assert.Nil(t, report.ReturnedMessage.Body)
+		}
+	}
+}
+
+func Test_IsFeedbackReport(t *testing.T) {
+	msg, _ := mail.ReadMessage(strings.NewReader(testFeedbackMessage))
+	assert.True(t, IsFeedbackReport(msg))
+
+	msg, _ = mail.ReadMessage(strings.NewReader(testDSNMessage))
+	assert.False(t, IsFeedbackReport(msg))
+
+	assert.False(t, IsFeedbackReport(nil))
+}
+
+func Test_ParseReport(t *testing.T) {
+	msg, _ := mail.ReadMessage(strings.NewReader(testFeedbackMessage))
+	report, err := rfc3464.ParseReport(msg, rfc3464.ParseOptions{})
+
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.True(t, report.IsKnown())
+	if feedback, ok := report.Value.(*FeedbackReport); assert.True(t, ok) {
+		assert.Equal(t, FeedbackAbuse, feedback.FeedbackType)
+	}
+}
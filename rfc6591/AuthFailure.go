@@ -0,0 +1,73 @@
+package rfc6591
+
+import (
+	"strings"
+
+	"github.com/YouDoCom/go-maildsnparsers/rfc3464"
+)
+
+/*
+AuthFailure is value of Auth-Failure field
+
+	Auth-Failure:  Indicates the type of authentication failure that
+	is being reported.
+
+	auth-failure = "Auth-Failure:" [CFWS] failure-type [CFWS] CRLF
+
+	failure-type = "adsp" / "bodyhash" / "revoked" / "signature" / "spf"
+
+RFC7489 adds "dmarc" failure type.
+*/
+type AuthFailure string
+
+// Registered failure types
+const (
+	AuthFailureADSP      AuthFailure = "adsp"
+	AuthFailureBodyHash  AuthFailure = "bodyhash"
+	AuthFailureRevoked   AuthFailure = "revoked"
+	AuthFailureSignature AuthFailure = "signature"
+	AuthFailureSPF       AuthFailure = "spf"
+	AuthFailureDMARC     AuthFailure = "dmarc"
+)
+
+// IsDKIM checks that failure is one of DKIM failures:
+// "bodyhash", "revoked" or "signature"
+func (f AuthFailure) IsDKIM() bool {
+	switch f {
+	case AuthFailureBodyHash, AuthFailureRevoked, AuthFailureSignature:
+		return true
+	}
+	return false
+}
+
+/*
+DeliveryResult is value of Delivery-Result field
+
+	Delivery-Result:  The final message disposition that was enacted by
+	the ADMD generating the report.
+
+	delivery-result = "Delivery-Result:" [CFWS]
+		( "delivered" / "spam" / "policy" / "reject" / "other" )
+		[CFWS] CRLF
+*/
+type DeliveryResult string
+
+// Registered delivery results
+const (
+	DeliveryResultDelivered DeliveryResult = "delivered"
+	DeliveryResultSpam      DeliveryResult = "spam"
+	DeliveryResultPolicy    DeliveryResult = "policy"
+	DeliveryResultReject    DeliveryResult = "reject"
+	DeliveryResultOther     DeliveryResult = "other"
+)
+
+// IsDelivered checks that message was delivered, possibly to spam folder
+func (r DeliveryResult) IsDelivered() bool {
+	return r == DeliveryResultDelivered || r == DeliveryResultSpam
+}
+
+// normalizeToken returns lower-cased value without comments
+func normalizeToken(value string) string {
+	value, _ = rfc3464.SplitComments(value)
+	return strings.ToLower(value)
+}
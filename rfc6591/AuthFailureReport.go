@@ -0,0 +1,162 @@
+package rfc6591
+
+import (
+	"encoding/base64"
+	"strings"
+
+	"github.com/YouDoCom/go-maildsnparsers/rfc3464"
+	"github.com/YouDoCom/go-maildsnparsers/rfc5965"
+)
+
+/*
+AuthFailureReport is RFC6591 authentication failure report: RFC5965
+feedback report with "auth-failure" Feedback-Type and fields describing
+the failure. Feedback report fields are available through embedded
+rfc5965.FeedbackReport, its Extensions contain fields not defined
+by RFC5965 and RFC6591.
+
+	The fields defined in this memo are used to provide details of
+	authentication failures detected on a message, and are added to the
+	machine-readable part of the ARF report.
+*/
+type AuthFailureReport struct {
+	rfc5965.FeedbackReport
+
+	/*
+		Auth-Failure:  Indicates the type of authentication failure that
+		is being reported.  This field MUST appear exactly once.
+	*/
+	AuthFailure AuthFailure
+
+	/*
+		Delivery-Result:  The final message disposition that was enacted by
+		the ADMD generating the report.
+	*/
+	DeliveryResult DeliveryResult
+
+	/*
+		DKIM-Domain:  The domain that signed the message, taken from the "d="
+		tag of the signature.
+	*/
+	DKIMDomain string
+
+	/*
+		DKIM-Identity:  The identity of the user or agent on whose behalf the
+		message was signed, taken from the "i=" tag of the signature.
+	*/
+	DKIMIdentity string
+
+	/*
+		DKIM-Selector:  The selector used to retrieve the public key,
+		taken from the "s=" tag of the signature.
+	*/
+	DKIMSelector string
+
+	/*
+		DKIM-Canonicalized-Header:  A base64 encoding of the canonicalized
+		header of the message as generated by the verifier.
+	*/
+	DKIMCanonicalizedHeader string
+
+	/*
+		DKIM-Canonicalized-Body:  A base64 encoding of the canonicalized body
+		of the message as generated by the verifier.
+	*/
+	DKIMCanonicalizedBody string
+
+	/*
+		DKIM-ADSP-DNS:  The retrieved DNS record used to determine the ADSP
+		result, if any.
+	*/
+	DKIMADSPDNS string
+
+	/*
+		SPF-DNS:  The retrieved DNS records used in the evaluation of SPF,
+		if any.  This field MAY appear more than once.
+	*/
+	SPFDNS []string
+
+	// IdentityAlignment lists lower-cased mechanisms of RFC7489
+	// Identity-Alignment field, e.g. "dkim", "spf" or "none"
+	IdentityAlignment []string
+}
+
+// FromFeedbackReport returns authentication failure report of feedback report,
+// e.g. one found by rfc3464.ParseReport. Fields defined by RFC6591 are removed
+// from Extensions of returned report, feedback is not modified.
+// ErrorAuthFailureNotFound is returned when Feedback-Type is not "auth-failure".
+func FromFeedbackReport(feedback *rfc5965.FeedbackReport) (*AuthFailureReport, error) {
+	if feedback == nil || feedback.FeedbackType != rfc5965.FeedbackAuthFailure {
+		return nil, ErrorAuthFailureNotFound
+	}
+
+	report := &AuthFailureReport{FeedbackReport: *feedback}
+	report.Extensions = make(rfc3464.Extensions, len(feedback.Extensions))
+	for k, v := range feedback.Extensions {
+		report.Extensions[k] = v
+	}
+
+	report.AuthFailure = AuthFailure(normalizeToken(report.takeField("Auth-Failure")))
+	report.DeliveryResult = DeliveryResult(normalizeToken(report.takeField("Delivery-Result")))
+	report.DKIMDomain = strings.TrimSpace(report.takeField("DKIM-Domain"))
+	report.DKIMIdentity = strings.TrimSpace(report.takeField("DKIM-Identity"))
+	report.DKIMSelector = strings.TrimSpace(report.takeField("DKIM-Selector"))
+	report.DKIMCanonicalizedHeader = report.takeField("DKIM-Canonicalized-Header")
+	report.DKIMCanonicalizedBody = report.takeField("DKIM-Canonicalized-Body")
+	report.DKIMADSPDNS = report.takeField("DKIM-ADSP-DNS")
+
+	if value := report.takeField("SPF-DNS"); value != "" {
+		report.SPFDNS = strings.Split(value, "\n")
+	}
+
+	for _, mechanism := range strings.Split(normalizeToken(report.takeField("Identity-Alignment")), ",") {
+		if mechanism = strings.TrimSpace(mechanism); mechanism != "" {
+			report.IdentityAlignment = append(report.IdentityAlignment, mechanism)
+		}
+	}
+
+	return report, nil
+}
+
+// takeField returns value of field and removes it from Extensions.
+// Values of repeated fields are separated by line breaks.
+func (r *AuthFailureReport) takeField(name string) string {
+	value := r.Extensions.Get(name)
+	r.Extensions.Del(name)
+	return value
+}
+
+// CanonicalizedHeader returns decoded DKIM-Canonicalized-Header field
+func (r *AuthFailureReport) CanonicalizedHeader() ([]byte, error) {
+	return decodeBase64(r.DKIMCanonicalizedHeader)
+}
+
+// CanonicalizedBody returns decoded DKIM-Canonicalized-Body field
+func (r *AuthFailureReport) CanonicalizedBody() ([]byte, error) {
+	return decodeBase64(r.DKIMCanonicalizedBody)
+}
+
+// IsAligned checks that Identity-Alignment field lists mechanism,
+// e.g. "dkim" or "spf". Mechanism is case-insensitive.
+func (r *AuthFailureReport) IsAligned(mechanism string) bool {
+	for _, m := range r.IdentityAlignment {
+		if strings.EqualFold(m, mechanism) {
+			return true
+		}
+	}
+	return false
+}
+
+// decodeBase64 decodes base64 value, white space is ignored
+func decodeBase64(value string) ([]byte, error) {
+	value = strings.Join(strings.Fields(value), "")
+	if value == "" {
+		return nil, ErrorFieldNotPresent
+	}
+
+	data, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrorInvalidEncoding
+	}
+	return data, nil
+}
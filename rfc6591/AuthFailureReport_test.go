@@ -0,0 +1,59 @@
+package rfc6591
+
+import (
+	"testing"
+
+	"github.com/YouDoCom/go-maildsnparsers/rfc3464"
+	"github.com/YouDoCom/go-maildsnparsers/rfc5965"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FromFeedbackReport(t *testing.T) {
+	feedback := &rfc5965.FeedbackReport{
+		FeedbackType: rfc5965.FeedbackAuthFailure,
+		Version:      "1",
+		Extensions: rfc3464.Extensions{
+			"Auth-Failure":              "SPF (policy)",
+			"Delivery-Result":           "Reject",
+			"Spf-Dns":                   "txt : example.net : \"v=spf1 -all\"\ntxt : mail.example.net : \"v=spf1 a -all\"",
+			"Identity-Alignment":        "DKIM, spf",
+			"Dkim-Canonicalized-Header": "RnJvbTogdXNlckBl eGFtcGxlLmNvbQ==",
+			"X-Other":                   "kept",
+		},
+	}
+
+	report, err := FromFeedbackReport(feedback)
+
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, AuthFailureSPF, report.AuthFailure)
+	assert.Equal(t, DeliveryResultReject, report.DeliveryResult)
+	assert.Equal(t, []string{`txt : example.net : "v=spf1 -all"`, `txt : mail.example.net : "v=spf1 a -all"`}, report.SPFDNS)
+	assert.Equal(t, []string{"dkim", "spf"}, report.IdentityAlignment)
+	assert.True(t, report.IsAligned("SPF"))
+	assert.Equal(t, "1", report.Version)
+	assert.Equal(t, rfc3464.Extensions{"X-Other": "kept"}, report.Extensions)
+	assert.Len(t, feedback.Extensions, 6)
+
+	header, err := report.CanonicalizedHeader()
+	if assert.NoError(t, err) {
+		assert.Equal(t, "From: user@example.com", string(header))
+	}
+
+	_, err = report.CanonicalizedBody()
+	assert.Equal(t, ErrorFieldNotPresent, err)
+
+	report.DKIMCanonicalizedBody = "not base64!"
+	_, err = report.CanonicalizedBody()
+	assert.Equal(t, ErrorInvalidEncoding, err)
+}
+
+func Test_FromFeedbackReport_NotAuthFailure(t *testing.T) {
+	_, err := FromFeedbackReport(&rfc5965.FeedbackReport{FeedbackType: rfc5965.FeedbackAbuse})
+	assert.Equal(t, ErrorAuthFailureNotFound, err)
+
+	_, err = FromFeedbackReport(nil)
+	assert.Equal(t, ErrorAuthFailureNotFound, err)
+}
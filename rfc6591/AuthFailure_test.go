@@ -0,0 +1,46 @@
+package rfc6591
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_AuthFailure_IsDKIM(t *testing.T) {
+	type fixture struct {
+		value    AuthFailure
+		expected bool
+	}
+
+	fixtures := []fixture{
+		fixture{value: AuthFailureBodyHash, expected: true},
+		fixture{value: AuthFailureRevoked, expected: true},
+		fixture{value: AuthFailureSignature, expected: true},
+		fixture{value: AuthFailureADSP, expected: false},
+		fixture{value: AuthFailureSPF, expected: false},
+		fixture{value: AuthFailureDMARC, expected: false},
+	}
+
+	for _, f := range fixtures {
+		assert.Equal(t, f.expected, f.value.IsDKIM(), "Fixture: %q", f.value)
+	}
+}
+
+func Test_DeliveryResult_IsDelivered(t *testing.T) {
+	type fixture struct {
+		value    DeliveryResult
+		expected bool
+	}
+
+	fixtures := []fixture{
+		fixture{value: DeliveryResultDelivered, expected: true},
+		fixture{value: DeliveryResultSpam, expected: true},
+		fixture{value: DeliveryResultPolicy, expected: false},
+		fixture{value: DeliveryResultReject, expected: false},
+		fixture{value: DeliveryResultOther, expected: false},
+	}
+
+	for _, f := range fixtures {
+		assert.Equal(t, f.expected, f.value.IsDelivered(), "Fixture: %q", f.value)
+	}
+}
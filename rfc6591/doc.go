@@ -0,0 +1,11 @@
+// Package rfc6591 authentication failure reports parser
+//
+// "Authentication Failure Reporting Using the Abuse Reporting Format"
+//
+// https://tools.ietf.org/html/rfc6591
+//
+// Authentication failure reports, e.g. DMARC failure (forensic) reports,
+// are RFC5965 feedback reports with "auth-failure" Feedback-Type.
+// Parsing is built on package rfc5965, so report is searched in message
+// the same way as by rfc3464 and rfc5965 parsers.
+package rfc6591
@@ -0,0 +1,32 @@
+package rfc6591
+
+import (
+	"errors"
+
+	"github.com/YouDoCom/go-maildsnparsers/dsnerrors"
+	"github.com/YouDoCom/go-maildsnparsers/rfc5965"
+)
+
+// parserName is used as ParseError.Parser
+const parserName = "rfc6591"
+
+var (
+	// ErrorNilMessage returned when message is nil
+	ErrorNilMessage = dsnerrors.ErrorNilMessage
+
+	// ErrorDSNNotFound matches every error returned when message is not a report
+	ErrorDSNNotFound = dsnerrors.ErrorDSNNotFound
+
+	// ErrorAuthFailureNotFound returned when message does not contain
+	// feedback report of "auth-failure" type
+	ErrorAuthFailureNotFound = dsnerrors.Derive(ErrorDSNNotFound, "Authentication failure report not found in message")
+
+	// ErrorLimitExceeded matches LimitError returned when message exceeds ParseOptions limits
+	ErrorLimitExceeded = dsnerrors.ErrorLimitExceeded
+
+	// ErrorFieldNotPresent returned when requested field is absent or empty
+	ErrorFieldNotPresent = rfc5965.ErrorFieldNotPresent
+
+	// ErrorInvalidEncoding returned when DKIM-Canonicalized field is not valid base64
+	ErrorInvalidEncoding = errors.New("Invalid base64 encoding")
+)
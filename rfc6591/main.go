@@ -0,0 +1,62 @@
+package rfc6591
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/mail"
+
+	"github.com/YouDoCom/go-maildsnparsers/dsnerrors"
+	"github.com/YouDoCom/go-maildsnparsers/rfc3464"
+	"github.com/YouDoCom/go-maildsnparsers/rfc5965"
+)
+
+// Parse parses RFC6591 authentication failure report from mail message
+// with default options
+func Parse(message *mail.Message) (*AuthFailureReport, error) {
+	return ParseWithOptions(message, rfc3464.ParseOptions{})
+}
+
+// ParseReader parses RFC6591 authentication failure report from raw message.
+// See rfc3464.ParseReader.
+func ParseReader(ctx context.Context, r io.Reader, opts rfc3464.ParseOptions) (*AuthFailureReport, error) {
+	feedback, err := rfc5965.ParseReader(ctx, r, opts)
+	return fromFeedback(feedback, err)
+}
+
+// ParseBytes parses RFC6591 authentication failure report from raw message.
+// See ParseReader.
+func ParseBytes(ctx context.Context, data []byte, opts rfc3464.ParseOptions) (*AuthFailureReport, error) {
+	return ParseReader(ctx, bytes.NewReader(data), opts)
+}
+
+// ParseWithOptions parses RFC6591 authentication failure report from mail message.
+//
+// Feedback report is searched by rfc5965.ParseWithOptions.
+// ErrorAuthFailureNotFound is returned when message does not contain
+// feedback report or its Feedback-Type is not "auth-failure".
+func ParseWithOptions(message *mail.Message, opts rfc3464.ParseOptions) (*AuthFailureReport, error) {
+	feedback, err := rfc5965.ParseWithOptions(message, opts)
+	return fromFeedback(feedback, err)
+}
+
+// fromFeedback converts result of rfc5965 parser
+func fromFeedback(feedback *rfc5965.FeedbackReport, err error) (*AuthFailureReport, error) {
+	switch {
+	case errors.Is(err, ErrorNilMessage):
+		return nil, dsnerrors.Wrap(parserName, "", 0, ErrorNilMessage)
+	case errors.Is(err, ErrorDSNNotFound):
+		return nil, dsnerrors.Wrap(parserName, "", 0, ErrorAuthFailureNotFound)
+	}
+
+	report, convErr := FromFeedbackReport(feedback)
+	if convErr != nil {
+		if err != nil {
+			return nil, dsnerrors.WithParser(parserName, err)
+		}
+		return nil, dsnerrors.Wrap(parserName, "", 0, convErr)
+	}
+
+	return report, dsnerrors.WithParser(parserName, err)
+}
@@ -0,0 +1,138 @@
+package rfc6591
+
+import (
+	"context"
+	"errors"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"github.com/YouDoCom/go-maildsnparsers/dsnerrors"
+	"github.com/YouDoCom/go-maildsnparsers/rfc3464"
+	"github.com/stretchr/testify/assert"
+)
+
+const testAuthFailureMessage = `From: arf-daemon@example.com
+To: dkim-reports@example.net
+Subject: FW: You have a new bill from your bank
+Date: Sat, 8 Oct 2011 15:15:59 -0500 (CDT)
+Message-ID: <433689.81121.example@mta.mail.receiver.example>
+MIME-Version: 1.0
+Content-Type: multipart/report; report-type=feedback-report;
+      boundary="------------Boundary-00=_3BCR4Y7kX93yP9uUPRhg"
+
+--------------Boundary-00=_3BCR4Y7kX93yP9uUPRhg
+Content-Type: text/plain; charset="us-ascii"
+Content-Transfer-Encoding: 7bit
+
+This is an authentication failure report for an email message
+received from a.sender.example on 8 Oct 2011 20:15:58 +0000 (GMT).
+For more information about this format, please see [RFC6591].
+
+--------------Boundary-00=_3BCR4Y7kX93yP9uUPRhg
+Content-Type: message/feedback-report
+
+Feedback-Type: auth-failure
+User-Agent: Someisp!Mail-Feedback/1.0
+Version: 1
+Original-Mail-From: anexample.reply@a.sender.example
+Original-Envelope-Id: o3F52gxO029144
+Authentication-Results: mta1011.mail.tp2.receiver.example;
+      dkim=fail (bodyhash) header.d=sender.example
+Auth-Failure: bodyhash
+DKIM-Canonicalized-Body: VGhpcyBpcyBhIG1lc3NhZ2UgYm9keSB0
+      aGF0IGdvdCBtb2RpZmllZCBpbiB0cmFuc2l0Lgo=
+DKIM-Domain: sender.example
+DKIM-Identity: @sender.example
+DKIM-Selector: testkey
+Arrival-Date: 8 Oct 2011 20:15:58 +0000
+Source-IP: 192.0.2.1
+Reported-Domain: a.sender.example
+Reported-URI: http://www.sender.example/
+
+--------------Boundary-00=_3BCR4Y7kX93yP9uUPRhg
+Content-Type: text/rfc822-headers
+
+Authentication-Results: mta1011.mail.tp2.receiver.example;
+      dkim=fail (bodyhash) header.d=sender.example
+From: sender@sender.example
+To: recipient@receiver.example
+Subject: You have a new bill from your bank
+Message-ID: <8787KJKJ3K4J3K4J3K4J3.mail@sender.example>
+
+--------------Boundary-00=_3BCR4Y7kX93yP9uUPRhg--
+`
+
+func Test_Parse(t *testing.T) {
+	msg, _ := mail.ReadMessage(strings.NewReader(testAuthFailureMessage))
+	report, err := Parse(msg)
+
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, AuthFailureBodyHash, report.AuthFailure)
+	assert.True(t, report.AuthFailure.IsDKIM())
+	assert.Equal(t, DeliveryResult(""), report.DeliveryResult)
+	assert.Equal(t, "sender.example", report.DKIMDomain)
+	assert.Equal(t, "@sender.example", report.DKIMIdentity)
+	assert.Equal(t, "testkey", report.DKIMSelector)
+	assert.Empty(t, report.Extensions)
+
+	body, err := report.CanonicalizedBody()
+	if assert.NoError(t, err) {
+		assert.Equal(t, "This is a message body that got modified in transit.\n", string(body))
+	}
+
+	assert.Equal(t, "anexample.reply@a.sender.example", report.Sender())
+	assert.Equal(t, "192.0.2.1", report.SourceIP)
+	assert.Equal(t, []string{"a.sender.example"}, report.ReportedDomain)
+	assert.Contains(t, report.HumanReadable, "This is an authentication failure report")
+	if assert.NotNil(t, report.ReturnedMessage) {
+		assert.Equal(t, "8787KJKJ3K4J3K4J3K4J3.mail@sender.example", report.ReturnedMessage.MessageID())
+	}
+}
+
+func Test_Parse_NotFound(t *testing.T) {
+	fixtures := []string{
+		strings.Replace(testAuthFailureMessage, "Feedback-Type: auth-failure", "Feedback-Type: abuse", 1),
+		strings.Replace(testAuthFailureMessage, "report-type=feedback-report", "report-type=delivery-status", 1),
+		"Content-Type: text/plain\n\nHello\n",
+	}
+
+	for _, f := range fixtures {
+		msg, _ := mail.ReadMessage(strings.NewReader(f))
+		report, err := Parse(msg)
+
+		assert.Nil(t, report)
+		assert.True(t, errors.Is(err, ErrorAuthFailureNotFound), "Fixture: %q", f)
+		assert.True(t, errors.Is(err, ErrorDSNNotFound), "Fixture: %q", f)
+		assert.EqualError(t, err, "rfc6591: Authentication failure report not found in message")
+	}
+
+	_, err := Parse(nil)
+	assert.True(t, errors.Is(err, ErrorNilMessage))
+}
+
+func Test_Parse_Limit(t *testing.T) {
+	msg, _ := mail.ReadMessage(strings.NewReader(testAuthFailureMessage))
+	report, err := ParseWithOptions(msg, rfc3464.ParseOptions{MaxHeaderBytes: 128})
+
+	assert.Nil(t, report)
+	assert.True(t, errors.Is(err, ErrorLimitExceeded))
+
+	var parseErr *dsnerrors.ParseError
+	if assert.True(t, errors.As(err, &parseErr)) {
+		assert.Equal(t, "rfc6591", parseErr.Parser)
+		assert.Equal(t, "2", parseErr.Part)
+	}
+}
+
+func Test_ParseBytes(t *testing.T) {
+	data := strings.Replace(testAuthFailureMessage, "\n", "\r\n", -1)
+	report, err := ParseBytes(context.Background(), []byte(data), rfc3464.ParseOptions{})
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, "testkey", report.DKIMSelector)
+	}
+}
@@ -0,0 +1,183 @@
+package rfc8098
+
+import (
+	"strings"
+
+	"github.com/YouDoCom/go-maildsnparsers/rfc3464"
+)
+
+// Action modes of Disposition field
+const (
+	ActionModeManual    = "manual-action"
+	ActionModeAutomatic = "automatic-action"
+)
+
+// Sending modes of Disposition field
+const (
+	SendingModeManual    = "MDN-sent-manually"
+	SendingModeAutomatic = "MDN-sent-automatically"
+)
+
+// Disposition types of Disposition field
+const (
+	DispositionDisplayed  = "displayed"
+	DispositionDeleted    = "deleted"
+	DispositionDispatched = "dispatched"
+	DispositionProcessed  = "processed"
+)
+
+// ModifierError is disposition modifier indicating an error,
+// described by Error fields
+const ModifierError = "error"
+
+/*
+Disposition represents Disposition field of MDN
+
+	disposition-field =
+		"Disposition" ":" OWS disposition-mode OWS ";"
+		OWS disposition-type
+		[ OWS "/" OWS disposition-modifier
+		*( OWS "," OWS disposition-modifier ) ] OWS
+
+	disposition-mode = action-mode OWS "/" OWS sending-mode
+
+	action-mode = "manual-action" / "automatic-action"
+
+	sending-mode = "MDN-sent-manually" / "MDN-sent-automatically"
+
+	disposition-type = "displayed" / "deleted" / "dispatched" /
+		"processed"
+
+	disposition-modifier = "error" / disposition-modifier-extension
+
+Values are case-insensitive, known values are stored
+in canonical spelling, others as written.
+*/
+type Disposition struct {
+	// ActionMode is "manual-action" or "automatic-action"
+	ActionMode string
+	// SendingMode is "MDN-sent-manually" or "MDN-sent-automatically"
+	SendingMode string
+	// Type is disposition type, e.g. "displayed"
+	Type string
+	// Modifiers lists disposition modifiers, e.g. "error"
+	Modifiers []string
+}
+
+var canonicalTokens = func() map[string]string {
+	m := make(map[string]string)
+	for _, token := range []string{
+		ActionModeManual, ActionModeAutomatic,
+		SendingModeManual, SendingModeAutomatic,
+		DispositionDisplayed, DispositionDeleted, DispositionDispatched, DispositionProcessed,
+		ModifierError,
+	} {
+		m[strings.ToLower(token)] = token
+	}
+	return m
+}()
+
+// canonicalToken returns canonical spelling of known token
+func canonicalToken(token string) string {
+	token = strings.TrimSpace(token)
+	if canonical, ok := canonicalTokens[strings.ToLower(token)]; ok {
+		return canonical
+	}
+	return token
+}
+
+// ParseDisposition parses value of Disposition field. Comments are ignored.
+// ErrorInvalidDisposition is returned when disposition mode or type is missing.
+func ParseDisposition(value string) (Disposition, error) {
+	value, _ = rfc3464.SplitComments(value)
+
+	i := strings.IndexByte(value, ';')
+	if i < 0 {
+		return Disposition{}, ErrorInvalidDisposition
+	}
+
+	mode := strings.SplitN(value[:i], "/", 2)
+	if len(mode) != 2 {
+		return Disposition{}, ErrorInvalidDisposition
+	}
+
+	d := Disposition{
+		ActionMode:  canonicalToken(mode[0]),
+		SendingMode: canonicalToken(mode[1]),
+	}
+
+	dispositionType := value[i+1:]
+	if j := strings.IndexByte(dispositionType, '/'); j >= 0 {
+		for _, modifier := range strings.Split(dispositionType[j+1:], ",") {
+			if modifier = canonicalToken(modifier); modifier != "" {
+				d.Modifiers = append(d.Modifiers, modifier)
+			}
+		}
+		dispositionType = dispositionType[:j]
+	}
+	d.Type = canonicalToken(dispositionType)
+
+	if d.ActionMode == "" || d.SendingMode == "" || d.Type == "" {
+		return Disposition{}, ErrorInvalidDisposition
+	}
+
+	return d, nil
+}
+
+// String returns disposition in field value form,
+// e.g. "manual-action/MDN-sent-manually; displayed"
+func (d Disposition) String() string {
+	if d.Type == "" {
+		return ""
+	}
+
+	s := d.ActionMode + "/" + d.SendingMode + "; " + d.Type
+	if len(d.Modifiers) > 0 {
+		s += "/" + strings.Join(d.Modifiers, ",")
+	}
+	return s
+}
+
+// IsAutomatic indicates that disposition was performed automatically,
+// e.g. by mail filter, rather than by explicit user action
+func (d Disposition) IsAutomatic() bool {
+	return strings.EqualFold(d.ActionMode, ActionModeAutomatic)
+}
+
+// IsDisplayed indicates that the message has been displayed to the user
+func (d Disposition) IsDisplayed() bool {
+	return strings.EqualFold(d.Type, DispositionDisplayed)
+}
+
+// IsDeleted indicates that the message has been deleted without being displayed
+func (d Disposition) IsDeleted() bool {
+	return strings.EqualFold(d.Type, DispositionDeleted)
+}
+
+// IsDispatched indicates that the message has been sent somewhere
+// in some manner (e.g., printed, faxed, forwarded) without necessarily
+// having been previously displayed to the user
+func (d Disposition) IsDispatched() bool {
+	return strings.EqualFold(d.Type, DispositionDispatched)
+}
+
+// IsProcessed indicates that the message has been processed in some manner
+// (i.e., by some sort of rules or server) without being displayed to the user
+func (d Disposition) IsProcessed() bool {
+	return strings.EqualFold(d.Type, DispositionProcessed)
+}
+
+// HasModifier checks that disposition has modifier, name is case-insensitive
+func (d Disposition) HasModifier(name string) bool {
+	for _, m := range d.Modifiers {
+		if strings.EqualFold(m, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsError indicates that an error occurred that prevented successful processing
+func (d Disposition) IsError() bool {
+	return d.HasModifier(ModifierError)
+}
@@ -0,0 +1,88 @@
+package rfc8098
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseDisposition(t *testing.T) {
+	type fixture struct {
+		value    string
+		expected Disposition
+		err      error
+	}
+
+	fixtures := []fixture{
+		fixture{
+			value: "manual-action/MDN-sent-manually; displayed",
+			expected: Disposition{
+				ActionMode:  ActionModeManual,
+				SendingMode: SendingModeManual,
+				Type:        DispositionDisplayed,
+			},
+		},
+		fixture{
+			value: " Automatic-Action / mdn-sent-automatically ; DELETED ",
+			expected: Disposition{
+				ActionMode:  ActionModeAutomatic,
+				SendingMode: SendingModeAutomatic,
+				Type:        DispositionDeleted,
+			},
+		},
+		fixture{
+			value: "automatic-action/MDN-sent-automatically; processed/error, x-quarantined",
+			expected: Disposition{
+				ActionMode:  ActionModeAutomatic,
+				SendingMode: SendingModeAutomatic,
+				Type:        DispositionProcessed,
+				Modifiers:   []string{ModifierError, "x-quarantined"},
+			},
+		},
+		fixture{
+			value: "manual-action/MDN-sent-manually (user clicked) ; dispatched (forwarded)",
+			expected: Disposition{
+				ActionMode:  ActionModeManual,
+				SendingMode: SendingModeManual,
+				Type:        DispositionDispatched,
+			},
+		},
+		fixture{value: "displayed", err: ErrorInvalidDisposition},
+		fixture{value: "manual-action; displayed", err: ErrorInvalidDisposition},
+		fixture{value: "manual-action/MDN-sent-manually;", err: ErrorInvalidDisposition},
+		fixture{value: "", err: ErrorInvalidDisposition},
+	}
+
+	for _, f := range fixtures {
+		d, err := ParseDisposition(f.value)
+
+		assert.Equal(t, f.err, err, "Fixture: %q", f.value)
+		assert.Equal(t, f.expected, d, "Fixture: %q", f.value)
+	}
+}
+
+func Test_Disposition_String(t *testing.T) {
+	d, _ := ParseDisposition("AUTOMATIC-ACTION/mdn-sent-automatically; Deleted/Error")
+
+	assert.Equal(t, "automatic-action/MDN-sent-automatically; deleted/error", d.String())
+	assert.Equal(t, "", Disposition{}.String())
+}
+
+func Test_Disposition_Predicates(t *testing.T) {
+	d, _ := ParseDisposition("automatic-action/MDN-sent-automatically; processed/error")
+
+	assert.True(t, d.IsAutomatic())
+	assert.True(t, d.IsProcessed())
+	assert.False(t, d.IsDisplayed())
+	assert.False(t, d.IsDeleted())
+	assert.False(t, d.IsDispatched())
+	assert.True(t, d.IsError())
+	assert.True(t, d.HasModifier("ERROR"))
+	assert.False(t, d.HasModifier("x-quarantined"))
+
+	d, _ = ParseDisposition("manual-action/MDN-sent-manually; displayed")
+
+	assert.False(t, d.IsAutomatic())
+	assert.True(t, d.IsDisplayed())
+	assert.False(t, d.IsError())
+}
@@ -0,0 +1,256 @@
+package rfc8098
+
+import (
+	"net/textproto"
+	"strings"
+
+	"github.com/YouDoCom/go-maildsnparsers/dsnerrors"
+	"github.com/YouDoCom/go-maildsnparsers/internal/rfc822"
+	"github.com/YouDoCom/go-maildsnparsers/rfc3464"
+)
+
+/*
+MDN is RFC8098 Message Disposition Notification
+
+	The message/disposition-notification content-type is used to report
+	the disposition of a message after it has been successfully delivered
+	to a recipient.
+
+	disposition-notification-content =
+		[ reporting-ua-field CRLF ]
+		[ mdn-gateway-field CRLF ]
+		[ original-recipient-field CRLF ]
+		final-recipient-field CRLF
+		[ original-message-id-field CRLF ]
+		disposition-field CRLF
+		*( error-field CRLF )
+		*( extension-field CRLF )
+*/
+type MDN struct {
+	/*
+		3.2.1. The Reporting-UA Field
+
+			reporting-ua-field = "Reporting-UA" ":" OWS ua-name OWS [
+				";" OWS ua-product OWS ]
+
+			ua-name = *text-no-semi
+
+			ua-product = *([FWS] text)
+
+		The Reporting-UA field is defined as follows:
+
+		An MDN describes the disposition of a message after it has been
+		delivered to a recipient.  In all cases, the Reporting-UA is the MUA
+		that performed the disposition described in the MDN.
+	*/
+	ReportingUA ReportingUA
+
+	/*
+		3.2.2. The MDN-Gateway Field
+
+		The MDN-Gateway field indicates the name of the gateway or MTA that
+		translated a foreign (non-Internet) message disposition notification
+		into this MDN.
+
+			mdn-gateway-field = "MDN-Gateway" ":" OWS mta-name-type OWS
+				";" OWS mta-name OWS
+	*/
+	MDNGateway rfc3464.TypeValueField
+
+	/*
+		3.2.3. Original-Recipient Field
+
+		The Original-Recipient field indicates the original recipient address
+		as specified by the sender of the message for which the MDN is being
+		issued.
+
+			original-recipient-field =
+				"Original-Recipient" ":" OWS address-type OWS
+				";" OWS generic-address OWS
+	*/
+	OriginalRecipient rfc3464.TypeValueField
+
+	/*
+		3.2.4. Final-Recipient Field
+
+		The Final-Recipient field indicates the recipient for which the MDN
+		is being issued.  This field MUST be present.
+
+			final-recipient-field =
+				"Final-Recipient" ":" OWS address-type OWS
+				";" OWS generic-address OWS
+	*/
+	FinalRecipient rfc3464.TypeValueField
+
+	/*
+		3.2.5. Original-Message-ID Field
+
+		The Original-Message-ID field indicates the message-ID of the message
+		for which the MDN is being issued.
+
+			original-message-id-field =
+				"Original-Message-ID" ":" msg-id
+	*/
+	OriginalMessageID string
+
+	/*
+		3.2.6. Disposition Field
+
+		The Disposition field indicates the action performed by the
+		Reporting-MUA on behalf of the user.  This field MUST be present.
+	*/
+	Disposition Disposition
+
+	/*
+		3.2.7. Error Field
+
+		The Error field is used to supply additional information in the form
+		of text messages when the "error" disposition modifier appears.
+
+			error-field = "Error" ":" *([FWS] text)
+	*/
+	Errors []string
+
+	// Warnings lists Warning fields defined by obsoleted RFC3798
+	Warnings []string
+
+	// FieldErrors lists errors of malformed fields, e.g. Disposition,
+	// which were skipped. Zero value is kept for such fields.
+	FieldErrors []*rfc3464.RecordError
+
+	/*
+		3.3. Extension Fields
+
+		Additional MDN fields may be defined in the future by later revisions
+		or extensions to this specification.  Extension-field names beginning
+		with "X-" will never be defined as standard fields.
+	*/
+	Extensions rfc3464.Extensions
+
+	// Global is true for RFC6533 message/global-disposition-notification part
+	Global bool
+
+	// ReportType is lower-cased report-type parameter of multipart/report
+	ReportType string
+
+	// Path is location of multipart/report entity in parsed message
+	Path rfc3464.PartPath
+
+	// HumanReadable is decoded UTF-8 text of the first part of report
+	HumanReadable string
+
+	// ReturnedMessage is original message or its header returned
+	// in the third part. It is nil if report has no such part.
+	ReturnedMessage *rfc3464.ReturnedMessage
+}
+
+// ReportingUA represents Reporting-UA field: name of MUA host and its product
+type ReportingUA struct {
+	// Name is name of the host where MUA is running, e.g. "pc.example.com"
+	Name string
+	// Product is name and version of MUA, e.g. "Foomail 97.1"
+	Product string
+}
+
+// String returns string representation of Reporting-UA field
+func (ua ReportingUA) String() string {
+	if ua.Product != "" {
+		return ua.Name + "; " + ua.Product
+	}
+	return ua.Name
+}
+
+// ParseReportingUA parses value of Reporting-UA field
+func ParseReportingUA(value string) ReportingUA {
+	data := strings.SplitN(value, ";", 2)
+
+	ua := ReportingUA{Name: strings.TrimSpace(data[0])}
+	if len(data) == 2 {
+		ua.Product = strings.TrimSpace(data[1])
+	}
+	return ua
+}
+
+// OriginalAddress returns parsed Original-Recipient address.
+// Generic-address of the field is decoded as RFC3461 xtext.
+func (mdn *MDN) OriginalAddress() (rfc3464.Address, error) {
+	return rfc3464.ParseAddress(mdn.OriginalRecipient, true)
+}
+
+// FinalAddress returns parsed Final-Recipient address
+func (mdn *MDN) FinalAddress() (rfc3464.Address, error) {
+	return rfc3464.ParseAddress(mdn.FinalRecipient, false)
+}
+
+// MessageID returns Original-Message-ID without angle brackets
+func (mdn *MDN) MessageID() string {
+	id := strings.TrimSpace(mdn.OriginalMessageID)
+	return strings.TrimSuffix(strings.TrimPrefix(id, "<"), ">")
+}
+
+// fillFromFields fills MDN from fields of disposition notification.
+// Malformed Disposition is stored in FieldErrors, dsnerrors.MissingFieldError
+// is returned when Final-Recipient or Disposition is absent.
+func (mdn *MDN) fillFromFields(fields rfc3464.Fields) error {
+	mdn.Extensions = make(rfc3464.Extensions)
+
+	var (
+		keyReportingUA       = textproto.CanonicalMIMEHeaderKey("Reporting-UA")
+		keyMDNGateway        = textproto.CanonicalMIMEHeaderKey("MDN-Gateway")
+		keyOriginalRecipient = textproto.CanonicalMIMEHeaderKey("Original-Recipient")
+		keyFinalRecipient    = textproto.CanonicalMIMEHeaderKey("Final-Recipient")
+		keyOriginalMessageID = textproto.CanonicalMIMEHeaderKey("Original-Message-ID")
+		keyDisposition       = textproto.CanonicalMIMEHeaderKey("Disposition")
+		keyError             = textproto.CanonicalMIMEHeaderKey("Error")
+		keyWarning           = textproto.CanonicalMIMEHeaderKey("Warning")
+	)
+
+	for k, v := range fields.Header() {
+		val := strings.Join(v, "\n")
+
+		switch k {
+		case keyReportingUA:
+			mdn.ReportingUA = ParseReportingUA(val)
+		case keyMDNGateway:
+			mdn.MDNGateway = rfc3464.ParseTypeValueField(rfc822.StripComments(val))
+		case keyOriginalRecipient:
+			mdn.OriginalRecipient = parseAddressField(val)
+		case keyFinalRecipient:
+			mdn.FinalRecipient = parseAddressField(val)
+		case keyOriginalMessageID:
+			mdn.OriginalMessageID = strings.TrimSpace(val)
+		case keyDisposition:
+			var err error
+			if mdn.Disposition, err = ParseDisposition(v[0]); err != nil {
+				field := fields.Lookup("Disposition")[0]
+				mdn.FieldErrors = append(mdn.FieldErrors, &rfc3464.RecordError{
+					Line: field.Line,
+					Raw:  field.Name + ":" + field.Raw,
+					Err:  err,
+				})
+			}
+		case keyError:
+			mdn.Errors = v
+		case keyWarning:
+			mdn.Warnings = v
+		default:
+			mdn.Extensions.Set(k, val)
+		}
+	}
+
+	switch {
+	case mdn.FinalRecipient.Value == "":
+		return &dsnerrors.MissingFieldError{Field: "Final-Recipient"}
+	case fields.Count("Disposition") == 0:
+		return &dsnerrors.MissingFieldError{Field: "Disposition"}
+	}
+
+	return nil
+}
+
+// parseAddressField parses Original-Recipient or Final-Recipient field,
+// generic-address is kept verbatim. See rfc822.SplitAddressField.
+func parseAddressField(value string) rfc3464.TypeValueField {
+	addressType, address, _ := rfc822.SplitAddressField(value)
+	return rfc3464.TypeValueField{Type: addressType, Value: address}
+}
@@ -0,0 +1,53 @@
+package rfc8098
+
+import (
+	"testing"
+
+	"github.com/YouDoCom/go-maildsnparsers/rfc3464"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseReportingUA(t *testing.T) {
+	type fixture struct {
+		value    string
+		expected ReportingUA
+	}
+
+	fixtures := []fixture{
+		fixture{value: "pc.example.com; Foomail 97.1", expected: ReportingUA{Name: "pc.example.com", Product: "Foomail 97.1"}},
+		fixture{value: " pc.example.com ", expected: ReportingUA{Name: "pc.example.com"}},
+		fixture{value: "pc.example.com; Foomail; beta", expected: ReportingUA{Name: "pc.example.com", Product: "Foomail; beta"}},
+	}
+
+	for _, f := range fixtures {
+		ua := ParseReportingUA(f.value)
+
+		assert.Equal(t, f.expected, ua, "Fixture: %q", f.value)
+	}
+
+	assert.Equal(t, "pc.example.com; Foomail 97.1", ReportingUA{Name: "pc.example.com", Product: "Foomail 97.1"}.String())
+	assert.Equal(t, "pc.example.com", ReportingUA{Name: "pc.example.com"}.String())
+}
+
+func Test_MDN_Addresses(t *testing.T) {
+	mdn := &MDN{
+		OriginalRecipient: rfc3464.ParseTypeValueField("rfc822; user+2Btag@example.com"),
+		FinalRecipient:    rfc3464.ParseTypeValueField("rfc822; User@Example.com"),
+		OriginalMessageID: " <original@example.com> ",
+	}
+
+	addr, err := mdn.OriginalAddress()
+	if assert.NoError(t, err) {
+		assert.Equal(t, "user+tag@example.com", addr.Address)
+	}
+
+	addr, err = mdn.FinalAddress()
+	if assert.NoError(t, err) {
+		assert.Equal(t, "example.com", addr.Domain())
+	}
+
+	assert.Equal(t, "original@example.com", mdn.MessageID())
+
+	_, err = (&MDN{}).FinalAddress()
+	assert.Equal(t, ErrorFieldNotPresent, err)
+}
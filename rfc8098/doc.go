@@ -0,0 +1,11 @@
+// Package rfc8098 message disposition notifications parser
+//
+// "Message Disposition Notification"
+//
+// https://tools.ietf.org/html/rfc8098
+//
+// Message disposition notifications (read receipts) share multipart/report
+// envelope with delivery status notifications, so parsing is built on
+// multipart/report handling of package rfc3464. Importing this package
+// registers "disposition-notification" handler for rfc3464.ParseReport.
+package rfc8098
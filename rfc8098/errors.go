@@ -0,0 +1,42 @@
+package rfc8098
+
+import (
+	"errors"
+
+	"github.com/YouDoCom/go-maildsnparsers/dsnerrors"
+	"github.com/YouDoCom/go-maildsnparsers/rfc3464"
+)
+
+// parserName is used as ParseError.Parser
+const parserName = "rfc8098"
+
+var (
+	// ErrorNilMessage returned when message is nil
+	ErrorNilMessage = dsnerrors.ErrorNilMessage
+
+	// ErrorDSNNotFound matches every error returned when message is not a report
+	ErrorDSNNotFound = dsnerrors.ErrorDSNNotFound
+
+	// ErrorMDNNotFound returned when message does not contain disposition notification
+	ErrorMDNNotFound = dsnerrors.Derive(ErrorDSNNotFound, "MDN not found in message")
+
+	// ErrorMalformedField returned when line of disposition notification is not valid field
+	ErrorMalformedField = dsnerrors.ErrorMalformedField
+
+	// ErrorLimitExceeded matches LimitError returned when message exceeds ParseOptions limits
+	ErrorLimitExceeded = dsnerrors.ErrorLimitExceeded
+
+	// ErrorInvalidDisposition returned when Disposition field is not valid
+	ErrorInvalidDisposition = errors.New("Invalid disposition")
+
+	// ErrorFieldNotPresent returned when requested field is absent or empty
+	ErrorFieldNotPresent = rfc3464.ErrorFieldNotPresent
+
+	// ErrorUnexpectedReportValue returned when handler registered for
+	// disposition notification report-type does not return *MDN
+	ErrorUnexpectedReportValue = dsnerrors.Derive(rfc3464.ErrorUnexpectedReportValue, "Report value is not MDN")
+
+	// ErrorRequiredFieldMissing matches dsnerrors.MissingFieldError returned
+	// when Final-Recipient or Disposition field is absent
+	ErrorRequiredFieldMissing = dsnerrors.ErrorRequiredFieldMissing
+)
@@ -0,0 +1,127 @@
+package rfc8098
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/mail"
+
+	"github.com/YouDoCom/go-maildsnparsers/dsnerrors"
+	"github.com/YouDoCom/go-maildsnparsers/internal/fieldblock"
+	"github.com/YouDoCom/go-maildsnparsers/internal/message"
+	"github.com/YouDoCom/go-maildsnparsers/rfc3464"
+)
+
+// reportTypes lists report-type parameters of disposition notification reports
+var reportTypes = []string{"disposition-notification", "global-disposition-notification"}
+
+var dispositionNotificationHandler = rfc3464.ReportHandler{
+	MediaTypes: []string{"message/disposition-notification", "message/global-disposition-notification"},
+	Parse: func(mediatype string, body io.Reader, opts rfc3464.ParseOptions) (interface{}, error) {
+		mdn, err := parseNotification(body, opts)
+		if mdn != nil {
+			mdn.Global = mediatype == "message/global-disposition-notification"
+			return mdn, err
+		}
+		return nil, err
+	},
+}
+
+func init() {
+	for _, reportType := range reportTypes {
+		rfc3464.RegisterReportHandler(reportType, dispositionNotificationHandler)
+	}
+}
+
+// Parse parses RFC8098 Message Disposition Notification (MDN) from mail message
+// with default options
+func Parse(message *mail.Message) (*MDN, error) {
+	return ParseWithOptions(message, rfc3464.ParseOptions{})
+}
+
+// ParseReader parses RFC8098 Message Disposition Notification (MDN) from raw message.
+// See rfc3464.ParseReader.
+func ParseReader(ctx context.Context, r io.Reader, opts rfc3464.ParseOptions) (*MDN, error) {
+	msg, err := message.Read(ctx, r, message.HeaderLimit(opts.MaxHeaderBytes))
+	if err != nil {
+		return nil, dsnerrors.Wrap(parserName, "", 0, err)
+	}
+
+	return ParseWithOptions(msg, opts)
+}
+
+// ParseBytes parses RFC8098 Message Disposition Notification (MDN) from raw message.
+// See ParseReader.
+func ParseBytes(ctx context.Context, data []byte, opts rfc3464.ParseOptions) (*MDN, error) {
+	return ParseReader(ctx, bytes.NewReader(data), opts)
+}
+
+// ParseWithOptions parses RFC8098 Message Disposition Notification (MDN) from mail message.
+//
+// The multipart/report entity with "disposition-notification" report-type is searched
+// the same way as by rfc3464.ParseWithOptions, MDN.Path contains its location.
+// ErrorMDNNotFound is returned when message does not contain MDN,
+// including delivery status notifications and other reports.
+// dsnerrors.MissingFieldError is returned along with MDN when Final-Recipient or
+// Disposition is absent, malformed Disposition is listed in MDN.FieldErrors.
+func ParseWithOptions(message *mail.Message, opts rfc3464.ParseOptions) (*MDN, error) {
+	report, err := rfc3464.FindReport(message, opts, reportTypes...)
+
+	var mdn *MDN
+	if report != nil {
+		if mdn, _ = report.Value.(*MDN); mdn != nil {
+			mdn.ReportType = report.ReportType
+			mdn.Path = report.Path
+			mdn.HumanReadable = report.HumanReadable
+			mdn.ReturnedMessage = report.ReturnedMessage
+		}
+	}
+
+	switch {
+	case err == nil && mdn == nil:
+		return nil, dsnerrors.Wrap(parserName, report.Path.String(), 0, ErrorUnexpectedReportValue)
+	case err == nil:
+		return mdn, nil
+	case errors.Is(err, ErrorNilMessage):
+		return nil, dsnerrors.Wrap(parserName, "", 0, ErrorNilMessage)
+	case errors.Is(err, ErrorDSNNotFound):
+		return nil, dsnerrors.Wrap(parserName, "", 0, ErrorMDNNotFound)
+	}
+
+	return mdn, dsnerrors.WithParser(parserName, err)
+}
+
+// IsMDN checks that message is RFC8098 Message Disposition Notification (MDN):
+// multipart/report with "disposition-notification" report-type.
+//
+// See rfc3464.DetectReportType.
+func IsMDN(message *mail.Message) bool {
+	reportType, ok := rfc3464.DetectReportType(message)
+	if !ok {
+		return false
+	}
+
+	for _, t := range reportTypes {
+		if t == reportType {
+			return true
+		}
+	}
+	return false
+}
+
+// parseNotification parses fields of message/disposition-notification part
+func parseNotification(body io.Reader, opts rfc3464.ParseOptions) (*MDN, error) {
+	fields, err := fieldblock.ReadFields(body, message.HeaderLimit(opts.MaxHeaderBytes))
+	if err != nil && len(fields) == 0 {
+		return nil, err
+	}
+
+	// MDN is filled from fields preceding malformed line too
+	mdn := &MDN{}
+	if fillErr := mdn.fillFromFields(fields); err == nil {
+		err = fillErr
+	}
+
+	return mdn, err
+}
@@ -0,0 +1,284 @@
+package rfc8098
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"github.com/YouDoCom/go-maildsnparsers/dsnerrors"
+	"github.com/YouDoCom/go-maildsnparsers/rfc3464"
+	"github.com/stretchr/testify/assert"
+)
+
+const testMDNMessage = `From: user@example.com
+To: sender@example.org
+Subject: Read: Hello
+MIME-Version: 1.0
+Content-Type: multipart/report; report-type=disposition-notification; boundary="MDN"
+
+--MDN
+Content-Type: text/plain
+
+The message sent on 1997-02-24 was displayed.
+
+--MDN
+Content-Type: message/disposition-notification
+
+Reporting-UA: pc.example.com; Foomail 97.1
+Original-Recipient: rfc822;User@example.com
+Final-Recipient: rfc822;User@example.com
+Original-Message-ID: <199509192301.23456@example.org>
+Disposition: manual-action/MDN-sent-manually; displayed
+X-Read-Time: 1997-02-24 12:00
+
+--MDN
+Content-Type: text/rfc822-headers
+
+Subject: Hello
+Message-ID: <199509192301.23456@example.org>
+
+--MDN--
+`
+
+const testMDNErrorMessage = `Content-Type: multipart/report; report-type=disposition-notification; boundary="MDN"
+
+--MDN
+Content-Type: text/plain
+
+The message could not be processed.
+
+--MDN
+Content-Type: message/disposition-notification
+
+Final-Recipient: rfc822; user@example.com
+Disposition: automatic-action/MDN-sent-automatically; processed/error
+Error: Message could not be decrypted
+Error: Key expired
+
+--MDN--
+`
+
+const testDSNMessage = `Content-Type: multipart/report; report-type=delivery-status; boundary="DSN"
+
+--DSN
+Content-Type: text/plain
+
+Delivery failed.
+
+--DSN
+Content-Type: message/delivery-status
+
+Reporting-MTA: dns; mx.example.com
+
+Final-Recipient: rfc822; user@example.com
+Action: failed
+Status: 5.1.1
+
+--DSN--
+`
+
+func Test_Parse(t *testing.T) {
+	msg, _ := mail.ReadMessage(strings.NewReader(testMDNMessage))
+	mdn, err := Parse(msg)
+
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, ReportingUA{Name: "pc.example.com", Product: "Foomail 97.1"}, mdn.ReportingUA)
+	assert.Equal(t, rfc3464.TypeValueField{Type: "rfc822", Value: "User@example.com"}, mdn.OriginalRecipient)
+	assert.Equal(t, rfc3464.TypeValueField{Type: "rfc822", Value: "User@example.com"}, mdn.FinalRecipient)
+	assert.Equal(t, "<199509192301.23456@example.org>", mdn.OriginalMessageID)
+	assert.True(t, mdn.Disposition.IsDisplayed())
+	assert.False(t, mdn.Disposition.IsAutomatic())
+	assert.Equal(t, "1997-02-24 12:00", mdn.Extensions.Get("X-Read-Time"))
+	assert.False(t, mdn.Global)
+	assert.Equal(t, "disposition-notification", mdn.ReportType)
+	assert.Equal(t, "The message sent on 1997-02-24 was displayed.", mdn.HumanReadable)
+	if assert.NotNil(t, mdn.ReturnedMessage) {
+		assert.Equal(t, mdn.MessageID(), mdn.ReturnedMessage.MessageID())
+	}
+}
+
+func Test_Parse_Error(t *testing.T) {
+	msg, _ := mail.ReadMessage(strings.NewReader(testMDNErrorMessage))
+	mdn, err := Parse(msg)
+
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.True(t, mdn.Disposition.IsProcessed())
+	assert.True(t, mdn.Disposition.IsError())
+	assert.Equal(t, []string{"Message could not be decrypted", "Key expired"}, mdn.Errors)
+	assert.Nil(t, mdn.ReturnedMessage)
+}
+
+func Test_Parse_NotFound(t *testing.T) {
+	fixtures := []string{
+		testDSNMessage,
+		"Content-Type: text/plain\n\nHello\n",
+	}
+
+	for _, f := range fixtures {
+		msg, _ := mail.ReadMessage(strings.NewReader(f))
+		mdn, err := Parse(msg)
+
+		assert.Nil(t, mdn)
+		assert.True(t, errors.Is(err, ErrorMDNNotFound), "Fixture: %q", f)
+		assert.True(t, errors.Is(err, ErrorDSNNotFound), "Fixture: %q", f)
+		assert.EqualError(t, err, "rfc8098: MDN not found in message")
+	}
+
+	_, err := Parse(nil)
+	assert.True(t, errors.Is(err, ErrorNilMessage))
+}
+
+func Test_Parse_InvalidDisposition(t *testing.T) {
+	data := strings.Replace(testMDNMessage, "manual-action/MDN-sent-manually; displayed", "displayed", 1)
+
+	msg, _ := mail.ReadMessage(strings.NewReader(data))
+	mdn, err := Parse(msg)
+
+	assert.NoError(t, err)
+	if assert.NotNil(t, mdn) {
+		assert.Equal(t, "pc.example.com", mdn.ReportingUA.Name)
+		assert.Equal(t, "User@example.com", mdn.FinalRecipient.Value)
+		if assert.Len(t, mdn.FieldErrors, 1) {
+			assert.True(t, errors.Is(mdn.FieldErrors[0].Err, ErrorInvalidDisposition))
+			assert.Equal(t, "Disposition: displayed", mdn.FieldErrors[0].Raw)
+		}
+	}
+}
+
+func Test_Parse_RequiredFieldMissing(t *testing.T) {
+	type fixture struct {
+		field         string
+		expectedError string
+	}
+
+	fixtures := []fixture{
+		fixture{
+			field:         "Final-Recipient: rfc822;User@example.com\n",
+			expectedError: "rfc8098: part 2: Required field missing: Final-Recipient",
+		},
+		fixture{
+			field:         "Disposition: manual-action/MDN-sent-manually; displayed\n",
+			expectedError: "rfc8098: part 2: Required field missing: Disposition",
+		},
+	}
+
+	for _, f := range fixtures {
+		data := strings.Replace(testMDNMessage, f.field, "", 1)
+
+		msg, _ := mail.ReadMessage(strings.NewReader(data))
+		mdn, err := Parse(msg)
+
+		assert.True(t, errors.Is(err, ErrorRequiredFieldMissing), f.field)
+		assert.EqualError(t, err, f.expectedError, f.field)
+		if assert.NotNil(t, mdn, f.field) {
+			assert.Equal(t, "pc.example.com", mdn.ReportingUA.Name, f.field)
+		}
+	}
+}
+
+func Test_Parse_Limit(t *testing.T) {
+	msg, _ := mail.ReadMessage(strings.NewReader(testMDNMessage))
+	_, err := ParseWithOptions(msg, rfc3464.ParseOptions{MaxHeaderBytes: 64})
+
+	assert.True(t, errors.Is(err, ErrorLimitExceeded))
+
+	var parseErr *dsnerrors.ParseError
+	if assert.True(t, errors.As(err, &parseErr)) {
+		assert.Equal(t, "rfc8098", parseErr.Parser)
+		assert.Equal(t, "2", parseErr.Part)
+	}
+}
+
+func Test_ParseBytes(t *testing.T) {
+	data := strings.Replace(testMDNMessage, "\n", "\r\n", -1)
+	mdn, err := ParseBytes(context.Background(), []byte(data), rfc3464.ParseOptions{})
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, "User@example.com", mdn.FinalRecipient.Value)
+	}
+}
+
+func Test_IsMDN(t *testing.T) {
+	msg, _ := mail.ReadMessage(strings.NewReader(testMDNMessage))
+	assert.True(t, IsMDN(msg))
+
+	msg, _ = mail.ReadMessage(strings.NewReader(testDSNMessage))
+	assert.False(t, IsMDN(msg))
+
+	assert.False(t, IsMDN(nil))
+}
+
+func Test_ParseReport(t *testing.T) {
+	msg, _ := mail.ReadMessage(strings.NewReader(testMDNMessage))
+	report, err := rfc3464.ParseReport(msg, rfc3464.ParseOptions{})
+
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.True(t, report.IsKnown())
+	if mdn, ok := report.Value.(*MDN); assert.True(t, ok) {
+		assert.Equal(t, "pc.example.com", mdn.ReportingUA.Name)
+	}
+}
+
+func Test_Parse_MalformedField(t *testing.T) {
+	data := strings.Replace(testMDNMessage, "X-Read-Time: 1997-02-24 12:00", "malformed line", 1)
+
+	msg, _ := mail.ReadMessage(strings.NewReader(data))
+	mdn, err := Parse(msg)
+
+	assert.True(t, errors.Is(err, ErrorMalformedField))
+	if assert.NotNil(t, mdn) {
+		assert.Equal(t, "pc.example.com", mdn.ReportingUA.Name)
+		assert.True(t, mdn.Disposition.IsDisplayed())
+	}
+
+	var parseErr *dsnerrors.ParseError
+	if assert.True(t, errors.As(err, &parseErr)) {
+		assert.Equal(t, "2", parseErr.Part)
+		assert.Equal(t, 6, parseErr.Line)
+	}
+}
+
+func Test_Parse_AddressComments(t *testing.T) {
+	data := strings.Replace(testMDNMessage, "Original-Recipient: rfc822;User@example.com",
+		"Original-Recipient: x400 (gateway); /G=John/S=Doe(Sales)/O=Org/", 1)
+	data = strings.Replace(data, "Final-Recipient: rfc822;User@example.com",
+		"Final-Recipient: rfc822 (smtp); User@example.com", 1)
+
+	msg, _ := mail.ReadMessage(strings.NewReader(data))
+	mdn, err := Parse(msg)
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, rfc3464.TypeValueField{Type: "x400", Value: "/G=John/S=Doe(Sales)/O=Org/"}, mdn.OriginalRecipient)
+		assert.Equal(t, rfc3464.TypeValueField{Type: "rfc822", Value: "User@example.com"}, mdn.FinalRecipient)
+	}
+}
+
+func Test_Parse_UnexpectedReportValue(t *testing.T) {
+	rfc3464.RegisterReportHandler("disposition-notification", rfc3464.ReportHandler{
+		MediaTypes: []string{"message/disposition-notification"},
+		Parse: func(mediatype string, body io.Reader, opts rfc3464.ParseOptions) (interface{}, error) {
+			return "not an MDN", nil
+		},
+	})
+	defer rfc3464.RegisterReportHandler("disposition-notification", dispositionNotificationHandler)
+
+	msg, _ := mail.ReadMessage(strings.NewReader(testMDNMessage))
+	mdn, err := Parse(msg)
+
+	assert.Nil(t, mdn)
+	assert.True(t, errors.Is(err, ErrorUnexpectedReportValue))
+	assert.True(t, errors.Is(err, rfc3464.ErrorUnexpectedReportValue))
+	assert.EqualError(t, err, "rfc8098: Report value is not MDN")
+}
@@ -1,11 +1,14 @@
 package xfailedrecipients
 
-import "errors"
+import "github.com/YouDoCom/go-maildsnparsers/dsnerrors"
+
+// parserName is used as ParseError.Parser
+const parserName = "xfailedrecipients"
 
 var (
 	// ErrorNilMessage returned when message is nil
-	ErrorNilMessage = errors.New("Message is nil")
+	ErrorNilMessage = dsnerrors.ErrorNilMessage
 
 	// ErrorDSNNotFound retured when DSN cannot be found in message
-	ErrorDSNNotFound = errors.New("DSN not found in message")
+	ErrorDSNNotFound = dsnerrors.ErrorDSNNotFound
 )
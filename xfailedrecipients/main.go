@@ -1,8 +1,14 @@
 package xfailedrecipients
 
 import (
+	"bytes"
+	"context"
+	"io"
 	"net/mail"
 	"strings"
+
+	"github.com/YouDoCom/go-maildsnparsers/dsnerrors"
+	"github.com/YouDoCom/go-maildsnparsers/internal/message"
 )
 
 // IsDSN checks that message is valid X-Failed-Recipients Delivery Status Notification (DSN)
@@ -24,14 +30,14 @@ func IsDSN(message *mail.Message) bool {
 // Parse parses X-Failed-Recipients Delivery Status Notification (DSN) from mail message and returs failed recipients list
 func Parse(message *mail.Message) ([]string, error) {
 	if message == nil {
-		return nil, ErrorNilMessage
+		return nil, dsnerrors.Wrap(parserName, "", 0, ErrorNilMessage)
 	}
 
 	hdr := message.Header.Get("X-Failed-Recipients")
 	hdr = strings.TrimSpace(hdr)
 
 	if hdr == "" {
-		return nil, ErrorDSNNotFound
+		return nil, dsnerrors.Wrap(parserName, "", 0, ErrorDSNNotFound)
 	}
 
 	recipients := strings.FieldsFunc(hdr, func(r rune) bool {
@@ -45,3 +51,20 @@ func Parse(message *mail.Message) ([]string, error) {
 
 	return recipients, nil
 }
+
+// ParseReader parses X-Failed-Recipients Delivery Status Notification (DSN) from raw message.
+// Raw message is read like in rfc3464.ParseReader.
+func ParseReader(ctx context.Context, r io.Reader) ([]string, error) {
+	msg, err := message.Read(ctx, r, message.DefaultMaxHeaderBytes)
+	if err != nil {
+		return nil, dsnerrors.Wrap(parserName, "", 0, err)
+	}
+
+	return Parse(msg)
+}
+
+// ParseBytes parses X-Failed-Recipients Delivery Status Notification (DSN) from raw message.
+// See ParseReader.
+func ParseBytes(ctx context.Context, data []byte) ([]string, error) {
+	return ParseReader(ctx, bytes.NewReader(data))
+}
@@ -1,6 +1,8 @@
 package xfailedrecipients
 
 import (
+	"context"
+	"errors"
 	"net/mail"
 	"strings"
 	"testing"
@@ -70,7 +72,8 @@ a test `
 func Test_ParseNilMessage(t *testing.T) {
 	_, err := Parse(nil)
 
-	assert.EqualError(t, err, ErrorNilMessage.Error(), "Nil message")
+	assert.True(t, errors.Is(err, ErrorNilMessage), "Nil message")
+	assert.EqualError(t, err, "xfailedrecipients: Message is nil", "Nil message")
 }
 
 func Test_ParseInvalid(t *testing.T) {
@@ -103,7 +106,7 @@ a test `
 
 	_, err := Parse(msg)
 
-	assert.EqualError(t, err, ErrorDSNNotFound.Error(), "DSN not found")
+	assert.True(t, errors.Is(err, ErrorDSNNotFound), "DSN not found")
 }
 
 func Test_IsDSNValid(t *testing.T) {
@@ -194,3 +197,25 @@ a test `
 func Test_IsDSNNilMessage(t *testing.T) {
 	assert.False(t, IsDSN(nil), "DSN nil Message")
 }
+
+func Test_ParseBytes(t *testing.T) {
+	value := "\xEF\xBB\xBFFrom MAILER-DAEMON Mon Dec  5 20:08:12 2016\n" +
+		"X-Failed-Recipients: first@example.com;\n second@example.com\n" +
+		"this line is garbage\n" +
+		"Subject: Mail delivery failed\n\n" +
+		"body\n"
+
+	recipients, err := ParseBytes(context.Background(), []byte(value))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"first@example.com", "second@example.com"}, recipients)
+
+	_, err = ParseBytes(context.Background(), []byte("Subject: test\n\nbody"))
+	assert.True(t, errors.Is(err, ErrorDSNNotFound))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = ParseReader(ctx, strings.NewReader(value))
+	assert.True(t, errors.Is(err, context.Canceled))
+}
@@ -1,8 +1,14 @@
 package xmailerdaemon
 
 import (
+	"bytes"
+	"context"
+	"io"
 	"net/mail"
 	"strings"
+
+	"github.com/YouDoCom/go-maildsnparsers/dsnerrors"
+	"github.com/YouDoCom/go-maildsnparsers/internal/message"
 )
 
 // IsDSN checks that message is valid Delivery Status Notification (DSN)
@@ -27,7 +33,7 @@ func IsDSN(message *mail.Message) bool {
 // Parse parses Delivery Status Notification (DSN) from mail message and returs failed recipients list
 func Parse(message *mail.Message) ([]Result, error) {
 	if message == nil {
-		return nil, ErrorNilMessage
+		return nil, dsnerrors.Wrap(parserName, "", 0, ErrorNilMessage)
 	}
 
 	hdr := message.Header.Get("X-Mailer-Daemon-Recipients")
@@ -37,7 +43,7 @@ func Parse(message *mail.Message) ([]Result, error) {
 	reasonHdr = strings.TrimSpace(reasonHdr)
 
 	if hdr == "" || reasonHdr == "" {
-		return nil, ErrorDSNNotFound
+		return nil, dsnerrors.Wrap(parserName, "", 0, ErrorDSNNotFound)
 	}
 
 	recipients := strings.FieldsFunc(hdr, func(r rune) bool {
@@ -56,3 +62,20 @@ func Parse(message *mail.Message) ([]Result, error) {
 
 	return ret, nil
 }
+
+// ParseReader parses Delivery Status Notification (DSN) from raw message.
+// Raw message is read like in rfc3464.ParseReader.
+func ParseReader(ctx context.Context, r io.Reader) ([]Result, error) {
+	msg, err := message.Read(ctx, r, message.DefaultMaxHeaderBytes)
+	if err != nil {
+		return nil, dsnerrors.Wrap(parserName, "", 0, err)
+	}
+
+	return Parse(msg)
+}
+
+// ParseBytes parses Delivery Status Notification (DSN) from raw message.
+// See ParseReader.
+func ParseBytes(ctx context.Context, data []byte) ([]Result, error) {
+	return ParseReader(ctx, bytes.NewReader(data))
+}
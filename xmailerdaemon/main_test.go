@@ -1,6 +1,8 @@
 package xmailerdaemon
 
 import (
+	"context"
+	"errors"
 	"net/mail"
 	"strings"
 	"testing"
@@ -70,7 +72,8 @@ a test `
 func Test_ParseNilMessage(t *testing.T) {
 	_, err := Parse(nil)
 
-	assert.EqualError(t, err, ErrorNilMessage.Error(), "Nil message")
+	assert.True(t, errors.Is(err, ErrorNilMessage), "Nil message")
+	assert.EqualError(t, err, "xmailerdaemon: Message is nil", "Nil message")
 }
 
 func Test_ParseInvalid(t *testing.T) {
@@ -103,7 +106,7 @@ a test `
 
 	_, err := Parse(msg)
 
-	assert.EqualError(t, err, ErrorDSNNotFound.Error(), "DSN not found")
+	assert.True(t, errors.Is(err, ErrorDSNNotFound), "DSN not found")
 }
 
 func Test_IsDSNValid(t *testing.T) {
@@ -194,3 +197,24 @@ a test `
 func Test_IsDSNNilMessage(t *testing.T) {
 	assert.False(t, IsDSN(nil), "DSN nil Message")
 }
+
+func Test_ParseBytes(t *testing.T) {
+	value := "From MAILER-DAEMON Mon Dec  5 20:08:12 2016\r\n" +
+		"X-Mailer-Daemon-Recipients: first@example.com, second@example.com\n" +
+		"X-Mailer-Daemon-Error: user_not_found\n\n" +
+		"body\n"
+
+	results, err := ParseBytes(context.Background(), []byte(value))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Result{
+		Result{Address: "first@example.com", Reason: "user_not_found"},
+		Result{Address: "second@example.com", Reason: "user_not_found"},
+	}, results)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = ParseReader(ctx, strings.NewReader(value))
+	assert.True(t, errors.Is(err, context.Canceled))
+}